@@ -5,6 +5,7 @@ go 1.24.1
 require (
 	github.com/atotto/clipboard v0.1.4
 	github.com/hajimehoshi/ebiten/v2 v2.8.6
+	github.com/rivo/uniseg v0.4.7
 	golang.org/x/image v0.25.0
 )
 
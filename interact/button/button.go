@@ -2,14 +2,16 @@
 package button
 
 import (
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/clock"
 	"github.com/OrtheSnowJames/ebiten-interactive/interact/colorscheme"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/draw"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/paint"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/overlay"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/text"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 	"golang.org/x/image/font"
 	"image/color"
-	"math"
 )
 
 // Rect defines a rectangle with float32 coordinates.
@@ -25,24 +27,6 @@ func pointInRect(x, y float32, r Rect) bool {
 	return x >= r.X && x <= r.X+r.W && y >= r.Y && y <= r.Y+r.H
 }
 
-func lerpColor(c1, c2 color.RGBA, t float32) color.RGBA {
-	return color.RGBA{
-		R: uint8(float32(c2.R-c1.R)*t + float32(c1.R)),
-		G: uint8(float32(c2.G-c1.G)*t + float32(c1.G)),
-		B: uint8(float32(c2.B-c1.B)*t + float32(c1.B)),
-		A: uint8(float32(c2.A-c1.A)*t + float32(c1.A)),
-	}
-}
-
-func fade(c color.RGBA, factor float32) color.RGBA {
-	return color.RGBA{
-		R: c.R,
-		G: c.G,
-		B: c.B,
-		A: uint8(float32(c.A) * factor),
-	}
-}
-
 // DefaultFont is a package-level font face used for drawing text.
 // Ensure that you set this variable (e.g., in your initialization code) to a valid font.Face.
 var DefaultFont font.Face
@@ -66,8 +50,16 @@ type Button struct {
 	Invisible         bool
 	Uneditable        bool
 	UseRoundedCorners bool
-	UsePointyStyle    bool    // New field for pointy buttons
-	PointyAmount      float32 // How pointy the buttons are (arrow length)
+	UsePointyStyle    bool              // New field for pointy buttons
+	PointyAmount      float32           // How pointy the buttons are (arrow length)
+	Style             colorscheme.Style // Shadow, gradients and highlight; the zero Style is flat
+	Icon              *ebiten.Image     // Drawn beside the label; nil for a text-only button
+	IconPlacement     IconPlacement     // Where the icon sits relative to the label
+	IconSpacing       float32           // Gap between the icon and the label
+	IconSize          float32           // Height of the icon; zero matches the font size
+	IconTint          IconTint          // Icon colors per state; zero colors leave it untinted
+	Input             input.Input       // Input source; nil uses input.Default()
+	Clock             clock.Clock       // Frame timing for the animation; nil uses clock.Default()
 
 	prevMouseDown bool
 	clicked       bool
@@ -95,6 +87,7 @@ func NewButton(x, y, width, height float32, label string) *Button {
 		UseRoundedCorners: true,
 		UsePointyStyle:    false,
 		PointyAmount:      10.0, // Default pointy amount (arrow length)
+		IconSpacing:       5.0,
 		prevMouseDown:     false,
 		clicked:           false,
 	}
@@ -160,6 +153,16 @@ func (b *Button) SetPointyAmount(amount float32) {
 	b.PointyAmount = amount
 }
 
+// SetInput sets the input source the button reads from. Passing nil uses input.Default().
+func (b *Button) SetInput(in input.Input) {
+	b.Input = in
+}
+
+// SetClock sets the clock the hover and press animation is timed by. Passing nil uses clock.Default().
+func (b *Button) SetClock(c clock.Clock) {
+	b.Clock = c
+}
+
 // Update should be called every frame.
 func (b *Button) Update() {
 	if b.Uneditable {
@@ -172,11 +175,12 @@ func (b *Button) Update() {
 		return
 	}
 
-	mx, my := ebiten.CursorPosition()
+	in := input.Or(b.Input)
+	mx, my := in.CursorPosition()
 	mouseX, mouseY := float32(mx), float32(my)
-	b.IsHovered = pointInRect(mouseX, mouseY, b.Bounds)
+	b.IsHovered = pointInRect(mouseX, mouseY, b.Bounds) && !overlay.Blocks(b, mouseX, mouseY)
 
-	curMouseDown := ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	curMouseDown := in.IsMouseButtonPressed(ebiten.MouseButtonLeft)
 	if b.IsHovered && curMouseDown {
 		b.IsPressed = true
 	} else {
@@ -199,8 +203,7 @@ func (b *Button) Update() {
 	}
 
 	animationSpeed := float32(8.0)
-	// Assume a fixed frame time of 1/60 sec; in a real game, use actual delta time.
-	frameTime := float32(1.0 / 60.0)
+	frameTime := clock.Or(b.Clock).Delta()
 	if b.AnimationProgress < targetProgress {
 		b.AnimationProgress += frameTime * animationSpeed
 		if b.AnimationProgress > targetProgress {
@@ -220,49 +223,43 @@ func (b *Button) Draw(screen *ebiten.Image) {
 		return
 	}
 
-	var currentColor color.RGBA
-	if !b.Enabled {
-		currentColor = fade(b.BackgroundColor, 0.5)
-	} else {
-		if b.AnimationProgress <= 0.5 {
-			t := b.AnimationProgress * 2.0
-			currentColor = lerpColor(b.BackgroundColor, b.HoverColor, t)
-		} else {
-			t := (b.AnimationProgress - 0.5) * 2.0
-			currentColor = lerpColor(b.HoverColor, b.PressedColor, t)
-		}
-	}
+	background := b.background()
 
 	borderThickness := float32(2.0)
 	if b.IsPressed {
 		borderThickness = 3.0
 	}
 
-	// Draw the button rectangle with appropriate style
-	if b.UsePointyStyle {
-		drawPointyButton(screen, b.Bounds, b.PointyAmount, currentColor, b.BorderColor, borderThickness)
-	} else if b.UseRoundedCorners {
-		drawRoundedRect(screen, b.Bounds.X, b.Bounds.Y, b.Bounds.W, b.Bounds.H, b.CornerRadius, currentColor)
-		drawRoundedRectOutline(screen, b.Bounds.X, b.Bounds.Y, b.Bounds.W, b.Bounds.H, b.CornerRadius, borderThickness, b.BorderColor)
+	// Draw the button shape with appropriate style
+	if skin := b.Style.Skins.For(b.state()); skin != nil {
+		x, y, w, h := b.Bounds.X, b.Bounds.Y, b.Bounds.W, b.Bounds.H
+		paint.Shadow(screen, x, y, w, h, draw.Radii{}, b.Style)
+		skin.Draw(screen, x, y, w, h)
+	} else if b.UsePointyStyle {
+		drawPointyButton(screen, b.Bounds, b.PointyAmount, background, b.BorderColor, borderThickness, b.Style)
 	} else {
-		ebitenutil.DrawRect(screen, float64(b.Bounds.X), float64(b.Bounds.Y), float64(b.Bounds.W), float64(b.Bounds.H), currentColor)
-		drawRectOutline(screen, b.Bounds, borderThickness, b.BorderColor)
-	}
-
-	// Draw label text centered
-	if b.FontFace == nil {
-		return // Cannot draw text without a valid font face.
+		var radii draw.Radii
+		if b.UseRoundedCorners {
+			radii = draw.Uniform(b.CornerRadius)
+		}
+		x, y, w, h := b.Bounds.X, b.Bounds.Y, b.Bounds.W, b.Bounds.H
+		paint.Shadow(screen, x, y, w, h, radii, b.Style)
+		draw.FillRoundedRectGradient(screen, x, y, w, h, radii, paint.Gradient(background))
+		paint.Highlight(screen, x, y, w, h, radii, borderThickness, b.Style)
+		draw.StrokeRoundedRect(screen, x, y, w, h, radii, borderThickness, b.BorderColor)
 	}
-	bounds := text.BoundString(b.FontFace, b.Label)
-	textWidth := float32(bounds.Dx())
-	textX := b.Bounds.X + (b.Bounds.W-textWidth)/2.0
-	textY := b.Bounds.Y + (b.Bounds.H-float32(b.FontSize))/2.0
 
+	// Draw the icon and label centered
+	iconX, iconY, textX, textY := b.layout()
 	offsetX, offsetY := float32(0), float32(0)
 	if b.IsPressed {
 		offsetX, offsetY = 1.0, 1.0
 	}
+	b.drawIcon(screen, iconX+offsetX, iconY+offsetY)
 
+	if b.FontFace == nil || (b.Icon != nil && b.IconPlacement == IconOnly) {
+		return // No font to draw with, or the label is hidden.
+	}
 	text.Draw(screen, b.Label, b.FontFace, int(textX+offsetX), int(textY+offsetY)+int(b.FontSize), b.TextColor)
 }
 
@@ -271,99 +268,26 @@ func (b *Button) IsClicked() bool {
 	return b.Enabled && b.IsHovered && b.clicked
 }
 
-// Helper function to draw a rounded rectangle filled with color.
-func drawRoundedRect(screen *ebiten.Image, x, y, w, h, r float32, col color.RGBA) {
-	path := &vector.Path{}
-	// Clamp radius to half of width/height.
-	r = float32(math.Min(float64(r), float64(w/2)))
-	r = float32(math.Min(float64(r), float64(h/2)))
-
-	// Start at top-left corner.
-	path.MoveTo(x+r, y)
-	// Top edge
-	path.LineTo(x+w-r, y)
-	// Top-right corner arc
-	path.Arc(x+w-r, y+r, r, -90, 0, vector.Clockwise)
-	// Right edge
-	path.LineTo(x+w, y+h-r)
-	// Bottom-right corner arc
-	path.Arc(x+w-r, y+h-r, r, 0, 90, vector.Clockwise)
-	// Bottom edge
-	path.LineTo(x+r, y+h)
-	// Bottom-left corner arc
-	path.Arc(x+r, y+h-r, r, 90, 180, vector.Clockwise)
-	// Left edge
-	path.LineTo(x, y+r)
-	// Top-left corner arc
-	path.Arc(x+r, y+r, r, 180, 270, vector.Clockwise)
-	path.Close()
-
-	op := &ebiten.DrawTrianglesOptions{}
-	// Draw filled path
-	vertices, indices := path.AppendVerticesAndIndicesForFilling(nil, nil)
-	screen.DrawTriangles(vertices, indices, nil, op)
-}
-
-// Helper function to draw rounded rectangle outline.
-func drawRoundedRectOutline(screen *ebiten.Image, x, y, w, h, r, thickness float32, col color.RGBA) {
-	// For simplicity, we re-use the filled rounded rect as an outline.
-	// A full implementation would stroke the path.
-	drawRoundedRect(screen, x, y, w, h, r, col)
-}
-
-// Helper function to draw rectangle outline for non-rounded rectangles.
-func drawRectOutline(screen *ebiten.Image, r Rect, thickness float32, col color.RGBA) {
-	// Top line
-	ebitenutil.DrawLine(screen, float64(r.X), float64(r.Y), float64(r.X+r.W), float64(r.Y), col)
-	// Bottom line
-	ebitenutil.DrawLine(screen, float64(r.X), float64(r.Y+r.H), float64(r.X+r.W), float64(r.Y+r.H), col)
-	// Left line
-	ebitenutil.DrawLine(screen, float64(r.X), float64(r.Y), float64(r.X), float64(r.Y+r.H), col)
-	// Right line
-	ebitenutil.DrawLine(screen, float64(r.X+r.W), float64(r.Y), float64(r.X+r.W), float64(r.Y+r.H), col)
-}
-
-// New helper function to draw pointy buttons
-func drawPointyButton(screen *ebiten.Image, bounds Rect, pointyAmount float32, fillColor, borderColor color.RGBA, thickness float32) {
-	path := &vector.Path{}
-
-	// Calculate arrow points
-	leftPoint := bounds.X - pointyAmount
-	rightPoint := bounds.X + bounds.W + pointyAmount
+// drawPointyButton draws a button shaped like a double-headed arrow, with its
+// points sticking out pointyAmount past the left and right of bounds. The
+// shadow keeps the arrow shape but is never blurred, and there is no highlight.
+func drawPointyButton(screen *ebiten.Image, bounds Rect, pointyAmount float32, background colorscheme.Gradient, borderColor color.RGBA, thickness float32, style colorscheme.Style) {
 	verticalCenter := bounds.Y + bounds.H/2
-
-	// Draw the pointy shape
-	path.MoveTo(leftPoint, verticalCenter)            // Left point
-	path.LineTo(bounds.X, bounds.Y)                   // Top left
-	path.LineTo(bounds.X+bounds.W, bounds.Y)          // Top right
-	path.LineTo(rightPoint, verticalCenter)           // Right point
-	path.LineTo(bounds.X+bounds.W, bounds.Y+bounds.H) // Bottom right
-	path.LineTo(bounds.X, bounds.Y+bounds.H)          // Bottom left
-	path.Close()
-
-	// Fill the shape
-	vertices, indices := path.AppendVerticesAndIndicesForFilling(nil, nil)
-	op := &ebiten.DrawTrianglesOptions{}
-	op.FillRule = ebiten.EvenOdd
-
-	// Set the color for vertices
-	for i := range vertices {
-		vertices[i].ColorR = float32(fillColor.R) / 0xff
-		vertices[i].ColorG = float32(fillColor.G) / 0xff
-		vertices[i].ColorB = float32(fillColor.B) / 0xff
-		vertices[i].ColorA = float32(fillColor.A) / 0xff
+	points := [...]draw.Point{
+		{X: bounds.X - pointyAmount, Y: verticalCenter},            // Left point
+		{X: bounds.X, Y: bounds.Y},                                 // Top left
+		{X: bounds.X + bounds.W, Y: bounds.Y},                      // Top right
+		{X: bounds.X + bounds.W + pointyAmount, Y: verticalCenter}, // Right point
+		{X: bounds.X + bounds.W, Y: bounds.Y + bounds.H},           // Bottom right
+		{X: bounds.X, Y: bounds.Y + bounds.H},                      // Bottom left
 	}
-
-	whiteImage := ebiten.NewImage(1, 1)
-	whiteImage.Fill(color.White)
-
-	screen.DrawTriangles(vertices, indices, whiteImage, op)
-
-	// Draw outline
-	ebitenutil.DrawLine(screen, float64(leftPoint), float64(verticalCenter), float64(bounds.X), float64(bounds.Y), borderColor)
-	ebitenutil.DrawLine(screen, float64(bounds.X), float64(bounds.Y), float64(bounds.X+bounds.W), float64(bounds.Y), borderColor)
-	ebitenutil.DrawLine(screen, float64(bounds.X+bounds.W), float64(bounds.Y), float64(rightPoint), float64(verticalCenter), borderColor)
-	ebitenutil.DrawLine(screen, float64(rightPoint), float64(verticalCenter), float64(bounds.X+bounds.W), float64(bounds.Y+bounds.H), borderColor)
-	ebitenutil.DrawLine(screen, float64(bounds.X+bounds.W), float64(bounds.Y+bounds.H), float64(bounds.X), float64(bounds.Y+bounds.H), borderColor)
-	ebitenutil.DrawLine(screen, float64(bounds.X), float64(bounds.Y+bounds.H), float64(leftPoint), float64(verticalCenter), borderColor)
+	if s := style.Shadow; s != nil {
+		var shadow [len(points)]draw.Point
+		for i, pt := range points {
+			shadow[i] = draw.Point{X: pt.X + s.OffsetX, Y: pt.Y + s.OffsetY}
+		}
+		draw.FillPolygon(screen, shadow[:], s.Color)
+	}
+	draw.FillPolygonGradient(screen, points[:], paint.Gradient(background))
+	draw.StrokePolygon(screen, points[:], thickness, borderColor)
 }
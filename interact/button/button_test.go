@@ -0,0 +1,219 @@
+package button_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/button"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/draw"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/testutil"
+)
+
+func TestClick(t *testing.T) {
+	b := button.NewButton(10, 10, 100, 40, "OK")
+	h := testutil.NewHarness(b)
+	defer h.Close()
+
+	h.Press(50, 30)
+	if !b.IsPressed {
+		t.Fatal("button not pressed while mouse is down over it")
+	}
+	if b.IsClicked() {
+		t.Fatal("button clicked before release")
+	}
+	h.Release(50, 30)
+	if !b.IsClicked() {
+		t.Fatal("button not clicked after release")
+	}
+	h.Frame()
+	if b.IsClicked() {
+		t.Fatal("click reported for more than one frame")
+	}
+}
+
+func TestClickOutside(t *testing.T) {
+	b := button.NewButton(10, 10, 100, 40, "OK")
+	h := testutil.NewHarness(b)
+	defer h.Close()
+
+	h.Click(200, 200)
+	if b.IsClicked() || b.IsHovered {
+		t.Fatal("click outside the bounds affected the button")
+	}
+}
+
+func TestDisabledButtonIgnoresClicks(t *testing.T) {
+	b := button.NewButton(10, 10, 100, 40, "OK")
+	b.SetEnabled(false)
+	h := testutil.NewHarness(b)
+	defer h.Close()
+
+	h.Click(50, 30)
+	if b.IsClicked() {
+		t.Fatal("disabled button reported a click")
+	}
+}
+
+func TestPerButtonInput(t *testing.T) {
+	b := button.NewButton(10, 10, 100, 40, "OK")
+	own := testutil.NewFakeInput()
+	b.SetInput(own)
+	h := testutil.NewHarness(b)
+	defer h.Close()
+
+	// The harness input is ignored because the button has its own.
+	h.Press(50, 30)
+	if b.IsPressed {
+		t.Fatal("button read the default input instead of its own")
+	}
+	own.MoveCursor(50, 30)
+	h.Frame()
+	if !b.IsHovered {
+		t.Fatal("button did not read its own input")
+	}
+}
+
+func TestAnimationSpeedIndependentOfFrameRate(t *testing.T) {
+	progressAfter := func(frameTime float32, frames int) float32 {
+		b := button.NewButton(10, 10, 100, 40, "OK")
+		h := testutil.NewHarness(b)
+		defer h.Close()
+		h.Clock.Seconds = frameTime
+		h.Input.MoveCursor(50, 30)
+		h.Advance(frames)
+		return b.AnimationProgress
+	}
+
+	// 1/30 of a second of hovering, at 30, 60 and 120 updates a second.
+	slow, normal, fast := progressAfter(1.0/30, 1), progressAfter(1.0/60, 2), progressAfter(1.0/120, 4)
+	if abs(slow-normal) > 1e-4 || abs(fast-normal) > 1e-4 {
+		t.Fatalf("progress differs by frame rate: 30=%v 60=%v 120=%v", slow, normal, fast)
+	}
+	if normal <= 0 || normal >= 0.5 {
+		t.Fatalf("progress %v, want part way to the hover state", normal)
+	}
+}
+
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// roundedButton returns the triangles of a 100x40 button at (10, 10) with
+// 8px corners, split into its fill and its border by vertex color.
+func roundedButton(t *testing.T) (fill, border []ebiten.Vertex) {
+	t.Helper()
+	b := button.NewButton(10, 10, 100, 40, "")
+	b.FontFace = nil
+	b.BackgroundColor = color.RGBA{R: 255, G: 102, B: 0, A: 255}
+	b.SetCornerRadius(8)
+	vertices, _ := draw.Capture(func() { b.Draw(nil) })
+	for _, v := range vertices {
+		switch {
+		case v.ColorR == 1 && v.ColorG == 0.4 && v.ColorB == 0 && v.ColorA == 1:
+			fill = append(fill, v)
+		case v.ColorR == 0 && v.ColorG == 0 && v.ColorB == 0 && v.ColorA == 1:
+			border = append(border, v)
+		default:
+			t.Fatalf("vertex color %v %v %v %v is neither the fill nor the border color", v.ColorR, v.ColorG, v.ColorB, v.ColorA)
+		}
+	}
+	return fill, border
+}
+
+func TestRoundedRectFillUsesColor(t *testing.T) {
+	fill, _ := roundedButton(t)
+	if len(fill) == 0 {
+		t.Fatal("no triangles in the fill color")
+	}
+	minX, minY, maxX, maxY := fill[0].DstX, fill[0].DstY, fill[0].DstX, fill[0].DstY
+	for _, v := range fill {
+		minX, minY = min(minX, v.DstX), min(minY, v.DstY)
+		maxX, maxY = max(maxX, v.DstX), max(maxY, v.DstY)
+		if abs(v.DstX-10) < 0.01 && abs(v.DstY-10) < 0.01 {
+			t.Fatal("fill reaches the top left corner, want it rounded off")
+		}
+	}
+	if abs(minX-10) > 0.01 || abs(minY-10) > 0.01 || abs(maxX-110) > 0.01 || abs(maxY-50) > 0.01 {
+		t.Fatalf("fill spans (%v, %v) to (%v, %v), want the bounds", minX, minY, maxX, maxY)
+	}
+}
+
+func TestRoundedRectOutlineIsAStrokeInsideTheBounds(t *testing.T) {
+	const x, y, w, h, radius, thickness = 10, 10, 100, 40, 8, 2
+	_, border := roundedButton(t)
+	if len(border) == 0 {
+		t.Fatal("no triangles for the outline")
+	}
+	innerEdge := false
+	for _, v := range border {
+		if v.DstX < x-0.01 || v.DstX > x+w+0.01 || v.DstY < y-0.01 || v.DstY > y+h+0.01 {
+			t.Fatalf("outline vertex (%v, %v) outside the bounds", v.DstX, v.DstY)
+		}
+		// Unlike a filled shape, a stroke has vertices on its inner edge,
+		// here where the straight top edge meets the corners.
+		if v.DstX > x+radius-0.01 && v.DstX < x+w-radius+0.01 && abs(v.DstY-(y+thickness)) < 0.01 {
+			innerEdge = true
+		}
+	}
+	if !innerEdge {
+		t.Fatal("outline has no inner edge along the top")
+	}
+}
+
+func TestIconSizesToTheFont(t *testing.T) {
+	b := button.NewButton(0, 0, 200, 50, "Play")
+	b.SetIcon(ebiten.NewImage(64, 32), button.IconLeft)
+	if w, h := b.IconDrawSize(); w != 40 || h != 20 {
+		t.Fatalf("icon drawn at %vx%v, want 40x20 to match the font size", w, h)
+	}
+	b.SetIconSize(16)
+	if w, h := b.IconDrawSize(); w != 32 || h != 16 {
+		t.Fatalf("icon drawn at %vx%v, want 32x16", w, h)
+	}
+}
+
+func TestIconPlacement(t *testing.T) {
+	face := testutil.GoRegular(20)
+	const label = "Play"
+	textW := float32(text.BoundString(face, label).Dx())
+	b := button.NewButton(0, 0, 200, 60, label)
+	b.FontFace = face
+	b.SetIconSpacing(6)
+
+	// Beside the label, the icon and label are centred together.
+	b.SetIcon(ebiten.NewImage(20, 20), button.IconLeft)
+	iconX, iconY, textX, textY := b.Layout()
+	if textX != iconX+20+6 {
+		t.Errorf("left icon: label at x=%v, want right after the icon at %v", textX, iconX+26)
+	}
+	if left, right := iconX, 200-(textX+textW); abs(left-right) > 0.01 {
+		t.Errorf("left icon: margins %v and %v, want them equal", left, right)
+	}
+	if iconY != 20 || textY != 20 {
+		t.Errorf("left icon: icon at y=%v and label at y=%v, want both centred at 20", iconY, textY)
+	}
+
+	b.SetIcon(b.Icon, button.IconRight)
+	iconX, _, textX, _ = b.Layout()
+	if iconX != textX+textW+6 {
+		t.Errorf("right icon: icon at x=%v, want right after the label at %v", iconX, textX+textW+6)
+	}
+
+	b.SetIcon(b.Icon, button.IconTop)
+	iconX, iconY, _, textY = b.Layout()
+	if iconX != 90 || iconY != 7 || textY != 33 {
+		t.Errorf("top icon: icon at (%v, %v) and label at y=%v, want (90, 7) and 33", iconX, iconY, textY)
+	}
+
+	b.SetIcon(b.Icon, button.IconOnly)
+	iconX, iconY, _, _ = b.Layout()
+	if iconX != 90 || iconY != 20 {
+		t.Errorf("icon only: icon at (%v, %v), want centred at (90, 20)", iconX, iconY)
+	}
+}
@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: MIT
+package button
+
+// Layout exposes layout for tests.
+func (b *Button) Layout() (iconX, iconY, textX, textY float32) {
+	return b.layout()
+}
+
+// IconDrawSize exposes iconSize for tests.
+func (b *Button) IconDrawSize() (w, h float32) {
+	return b.iconSize()
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: MIT
+package button
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+// IconPlacement is where a button's icon sits relative to its label.
+type IconPlacement int
+
+const (
+	IconLeft  IconPlacement = iota // Icon before the label
+	IconRight                      // Icon after the label
+	IconTop                        // Icon above the label
+	IconOnly                       // Icon alone; the label is not drawn
+)
+
+// IconTint multiplies the icon's colors in each state. A zero color leaves the
+// icon untinted, except when disabled, where it is drawn at half opacity.
+type IconTint struct {
+	Normal, Hover, Pressed, Disabled color.RGBA
+}
+
+// SetIcon sets the image drawn on the button and where it sits. Passing nil
+// removes the icon.
+func (b *Button) SetIcon(icon *ebiten.Image, placement IconPlacement) {
+	b.Icon = icon
+	b.IconPlacement = placement
+}
+
+// SetIconSpacing sets the gap between the icon and the label.
+func (b *Button) SetIconSpacing(spacing float32) {
+	b.IconSpacing = spacing
+}
+
+// SetIconSize sets the height the icon is scaled to, keeping its aspect
+// ratio. Zero sizes it to the font.
+func (b *Button) SetIconSize(size float32) {
+	b.IconSize = size
+}
+
+// SetIconTint sets the colors the icon is tinted in each state.
+func (b *Button) SetIconTint(tint IconTint) {
+	b.IconTint = tint
+}
+
+// iconSize returns the size the icon is drawn at.
+func (b *Button) iconSize() (w, h float32) {
+	if b.Icon == nil {
+		return 0, 0
+	}
+	size := b.Icon.Bounds().Size()
+	if size.Y == 0 {
+		return 0, 0
+	}
+	h = b.IconSize
+	if h <= 0 {
+		h = float32(b.FontSize)
+	}
+	return float32(size.X) * h / float32(size.Y), h
+}
+
+// labelWidth returns the width of the label, or zero if it is not drawn.
+func (b *Button) labelWidth() float32 {
+	if b.FontFace == nil || b.Label == "" || (b.Icon != nil && b.IconPlacement == IconOnly) {
+		return 0
+	}
+	return float32(text.BoundString(b.FontFace, b.Label).Dx())
+}
+
+// layout centres the icon and label in the button, returning the top-left of
+// the icon and of the label's line box.
+func (b *Button) layout() (iconX, iconY, textX, textY float32) {
+	iconW, iconH := b.iconSize()
+	textW, textH := b.labelWidth(), float32(b.FontSize)
+	centerX := b.Bounds.X + b.Bounds.W/2
+	centerY := b.Bounds.Y + b.Bounds.H/2
+
+	gap := b.IconSpacing
+	if iconW == 0 || textW == 0 {
+		gap = 0
+	}
+	switch {
+	case b.Icon == nil || b.IconPlacement == IconOnly:
+		return centerX - iconW/2, centerY - iconH/2, centerX - textW/2, centerY - textH/2
+	case b.IconPlacement == IconTop:
+		if textW == 0 {
+			gap, textH = 0, 0
+		}
+		top := centerY - (iconH+gap+textH)/2
+		return centerX - iconW/2, top, centerX - textW/2, top + iconH + gap
+	case b.IconPlacement == IconRight:
+		left := centerX - (textW+gap+iconW)/2
+		return left + textW + gap, centerY - iconH/2, left, centerY - textH/2
+	default:
+		left := centerX - (iconW+gap+textW)/2
+		return left, centerY - iconH/2, left + iconW + gap, centerY - textH/2
+	}
+}
+
+// drawIcon draws the icon scaled to its size at x, y, tinted for the current state.
+func (b *Button) drawIcon(screen *ebiten.Image, x, y float32) {
+	w, h := b.iconSize()
+	if w == 0 {
+		return
+	}
+	size := b.Icon.Bounds().Size()
+	var op ebiten.DrawImageOptions
+	op.GeoM.Scale(float64(w)/float64(size.X), float64(h)/float64(size.Y))
+	op.GeoM.Translate(float64(x), float64(y))
+	op.Filter = ebiten.FilterLinear
+
+	var tint color.RGBA
+	switch {
+	case !b.Enabled:
+		tint = b.IconTint.Disabled
+		if tint == (color.RGBA{}) {
+			op.ColorScale.ScaleAlpha(0.5)
+		}
+	case b.IsPressed:
+		tint = b.IconTint.Pressed
+	case b.IsHovered:
+		tint = b.IconTint.Hover
+	default:
+		tint = b.IconTint.Normal
+	}
+	if tint != (color.RGBA{}) {
+		op.ColorScale.ScaleWithColor(tint)
+	}
+	screen.DrawImage(b.Icon, &op)
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+package button
+
+import (
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/colorscheme"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/paint"
+)
+
+// SetStyle sets the button's drop shadow, background gradients, highlight and
+// skins. States without a gradient keep their flat color.
+func (b *Button) SetStyle(style colorscheme.Style) {
+	b.Style = style
+}
+
+// background returns the background to draw this frame, blending between the
+// normal, hover and pressed backgrounds as the animation runs. A disabled
+// button without a disabled gradient is drawn faded.
+func (b *Button) background() colorscheme.Gradient {
+	if !b.Enabled {
+		if g := b.Style.Gradients.Disabled; g != nil {
+			return *g
+		}
+		normal := paint.Background(b.Style, colorscheme.Normal, b.BackgroundColor)
+		normal.From, normal.To = paint.Fade(normal.From, 0.5), paint.Fade(normal.To, 0.5)
+		return normal
+	}
+	normal := paint.Background(b.Style, colorscheme.Normal, b.BackgroundColor)
+	hover := paint.Background(b.Style, colorscheme.Hover, b.HoverColor)
+	if b.AnimationProgress <= 0.5 {
+		return paint.LerpGradient(normal, hover, b.AnimationProgress*2.0)
+	}
+	pressed := paint.Background(b.Style, colorscheme.Pressed, b.PressedColor)
+	return paint.LerpGradient(hover, pressed, (b.AnimationProgress-0.5)*2.0)
+}
+
+// state returns the state the button's skin is chosen by.
+func (b *Button) state() colorscheme.State {
+	switch {
+	case !b.Enabled:
+		return colorscheme.Disabled
+	case b.IsPressed:
+		return colorscheme.Pressed
+	case b.IsHovered:
+		return colorscheme.Hover
+	default:
+		return colorscheme.Normal
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+
+// Package clock tells widgets how much time passed since their last Update, so
+// animations, caret blinking and key repeat run at the same speed whatever
+// ebiten.SetTPS is set to.
+package clock
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Clock reports the length of the current frame.
+type Clock interface {
+	// Delta returns the seconds since the previous Update.
+	Delta() float32
+}
+
+// fallbackTPS is assumed when ebiten has no tick rate to report yet.
+const fallbackTPS = 60
+
+type ebitenClock struct{}
+
+// Ebiten returns the clock that follows ebiten's tick rate. Update runs TPS
+// times a second, so a frame lasts 1/TPS. With ebiten.SyncWithFPS the measured
+// ebiten.ActualTPS is used instead.
+func Ebiten() Clock {
+	return ebitenClock{}
+}
+
+func (ebitenClock) Delta() float32 {
+	tps := float64(ebiten.TPS())
+	if tps <= 0 {
+		tps = ebiten.ActualTPS()
+	}
+	if tps <= 0 {
+		tps = fallbackTPS
+	}
+	return float32(1 / tps)
+}
+
+// Fixed is a clock whose frames all last the same time, for tests and for games
+// that step their simulation by hand. Seconds may be changed between frames.
+type Fixed struct {
+	Seconds float32
+}
+
+// NewFixed returns a clock whose frames last the given number of seconds.
+func NewFixed(seconds float32) *Fixed {
+	return &Fixed{Seconds: seconds}
+}
+
+func (f *Fixed) Delta() float32 {
+	return f.Seconds
+}
+
+var defaultClock = Ebiten()
+
+// Default returns the clock used by widgets that have no clock of their own.
+func Default() Clock {
+	return defaultClock
+}
+
+// SetDefault replaces the clock used by widgets that have no clock of their own.
+// Passing nil restores the ebiten clock.
+func SetDefault(c Clock) {
+	if c == nil {
+		c = Ebiten()
+	}
+	defaultClock = c
+}
+
+// Or returns c if it is non-nil and the default clock otherwise.
+func Or(c Clock) Clock {
+	if c != nil {
+		return c
+	}
+	return defaultClock
+}
@@ -0,0 +1,43 @@
+package clock_test
+
+import (
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/clock"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestEbitenClockFollowsTPS(t *testing.T) {
+	prev := ebiten.TPS()
+	t.Cleanup(func() { ebiten.SetTPS(prev) })
+
+	for _, tps := range []int{30, 60, 120} {
+		ebiten.SetTPS(tps)
+		if got, want := clock.Ebiten().Delta(), float32(1)/float32(tps); got != want {
+			t.Errorf("at %d TPS: delta %v, want %v", tps, got, want)
+		}
+	}
+}
+
+func TestDefaultAndOr(t *testing.T) {
+	t.Cleanup(func() { clock.SetDefault(nil) })
+
+	fixed := clock.NewFixed(0.25)
+	clock.SetDefault(fixed)
+	if clock.Or(nil).Delta() != 0.25 {
+		t.Fatal("Or(nil) did not use the default clock")
+	}
+	own := clock.NewFixed(0.5)
+	if clock.Or(own).Delta() != 0.5 {
+		t.Fatal("Or ignored the widget's own clock")
+	}
+	fixed.Seconds = 0.1
+	if clock.Default().Delta() != 0.1 {
+		t.Fatal("changing Seconds did not change the frame time")
+	}
+
+	clock.SetDefault(nil)
+	if _, ok := clock.Default().(*clock.Fixed); ok {
+		t.Fatal("SetDefault(nil) did not restore the ebiten clock")
+	}
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+package colorscheme
+
+import (
+	"image/color"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/draw"
+)
+
+// State is the interaction state a widget is drawn in.
+type State int
+
+const (
+	Normal State = iota
+	Hover
+	Pressed
+	Disabled
+	Focused
+)
+
+// Gradient is a two-color background running from top to bottom, or from
+// left to right if Horizontal is set.
+type Gradient struct {
+	From, To   color.RGBA
+	Horizontal bool
+}
+
+// Flat returns a gradient that is c all over.
+func Flat(c color.RGBA) Gradient {
+	return Gradient{From: c, To: c}
+}
+
+// Gradients are the background gradients for each state. A nil gradient
+// leaves that state's background its flat color.
+type Gradients struct {
+	Normal, Hover, Pressed, Disabled, Focused *Gradient
+}
+
+// For returns the gradient for state, or nil if it has none.
+func (g Gradients) For(state State) *Gradient {
+	switch state {
+	case Hover:
+		return g.Hover
+	case Pressed:
+		return g.Pressed
+	case Disabled:
+		return g.Disabled
+	case Focused:
+		return g.Focused
+	default:
+		return g.Normal
+	}
+}
+
+// Shadow is a drop shadow cast below a widget.
+type Shadow struct {
+	OffsetX, OffsetY float32    // How far the shadow falls from the widget
+	Blur             float32    // Width of the soft edge; zero gives a hard shadow
+	Color            color.RGBA // Usually translucent black
+}
+
+// Highlight is a line of light just inside a widget's border that fades out
+// towards the bottom, giving the widget a raised or glossy look.
+type Highlight struct {
+	Width float32
+	Color color.RGBA
+}
+
+// Skins are nine-slice images that replace a widget's drawn background,
+// border and highlight. A state without a skin of its own uses the Normal
+// skin, and a widget without a Normal skin is drawn with shapes.
+type Skins struct {
+	Normal, Hover, Pressed, Disabled, Focused *draw.NineSlice
+}
+
+// For returns the skin for state, falling back to the Normal skin, or nil if
+// there is none.
+func (s Skins) For(state State) *draw.NineSlice {
+	var skin *draw.NineSlice
+	switch state {
+	case Hover:
+		skin = s.Hover
+	case Pressed:
+		skin = s.Pressed
+	case Disabled:
+		skin = s.Disabled
+	case Focused:
+		skin = s.Focused
+	}
+	if skin == nil {
+		skin = s.Normal
+	}
+	return skin
+}
+
+// Style adds depth to a widget on top of its flat colors. The zero Style draws
+// flat, as widgets always have.
+type Style struct {
+	Shadow    *Shadow
+	Gradients Gradients
+	Highlight *Highlight
+	Skins     Skins // Nine-slice images drawn instead of the background; the shadow is still drawn
+}
+
+// GlossyStyle returns a style derived from a color scheme: each state fades
+// from a lighter shade of its color at the top to the color itself, over a
+// soft shadow and under a white highlight.
+func GlossyStyle(scheme ColorScheme) Style {
+	glossy := func(c color.RGBA) *Gradient {
+		return &Gradient{From: Lighten(c, 0.35), To: c}
+	}
+	return Style{
+		Shadow: &Shadow{OffsetY: 3, Blur: 6, Color: color.RGBA{A: 96}},
+		Gradients: Gradients{
+			Normal:  glossy(scheme.Background),
+			Hover:   glossy(scheme.Hover),
+			Pressed: &Gradient{From: scheme.Pressed, To: Lighten(scheme.Pressed, 0.2)},
+		},
+		Highlight: &Highlight{Width: 1, Color: color.RGBA{R: 160, G: 160, B: 160, A: 160}}, // Translucent white
+	}
+}
+
+// Lighten moves c towards white by amount, from 0 (unchanged) to 1 (white),
+// keeping its alpha.
+func Lighten(c color.RGBA, amount float32) color.RGBA {
+	amount = max(0, min(amount, 1))
+	lighten := func(v uint8) uint8 {
+		// color.RGBA is premultiplied, so white at alpha A is A in every channel.
+		return v + uint8(float32(c.A-min(v, c.A))*amount)
+	}
+	return color.RGBA{R: lighten(c.R), G: lighten(c.G), B: lighten(c.B), A: c.A}
+}
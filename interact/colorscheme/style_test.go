@@ -0,0 +1,46 @@
+package colorscheme_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/colorscheme"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/draw"
+)
+
+func TestSkinsFallBackToNormal(t *testing.T) {
+	normal, pressed := &draw.NineSlice{}, &draw.NineSlice{}
+	skins := colorscheme.Skins{Normal: normal, Pressed: pressed}
+	if skins.For(colorscheme.Pressed) != pressed {
+		t.Error("pressed state does not use its own skin")
+	}
+	if skins.For(colorscheme.Hover) != normal {
+		t.Error("hover state without a skin does not use the normal skin")
+	}
+	if (colorscheme.Skins{Hover: pressed}).For(colorscheme.Normal) != nil {
+		t.Error("normal state without a skin has one")
+	}
+}
+
+func TestLighten(t *testing.T) {
+	if got := colorscheme.Lighten(color.RGBA{R: 100, G: 0, B: 200, A: 255}, 0.5); got != (color.RGBA{R: 177, G: 127, B: 227, A: 255}) {
+		t.Errorf("Lighten opaque = %v", got)
+	}
+	// Premultiplied: white at half alpha is 128 in every channel.
+	if got := colorscheme.Lighten(color.RGBA{A: 128}, 1); got != (color.RGBA{R: 128, G: 128, B: 128, A: 128}) {
+		t.Errorf("Lighten translucent = %v", got)
+	}
+}
+
+func TestGlossyStyleColorsArePremultiplied(t *testing.T) {
+	style := colorscheme.GlossyStyle(colorscheme.BlueScheme())
+	cols := []color.RGBA{style.Shadow.Color, style.Highlight.Color}
+	for _, g := range []*colorscheme.Gradient{style.Gradients.Normal, style.Gradients.Hover, style.Gradients.Pressed} {
+		cols = append(cols, g.From, g.To)
+	}
+	for _, c := range cols {
+		if c.R > c.A || c.G > c.A || c.B > c.A {
+			t.Errorf("color %v is not premultiplied", c)
+		}
+	}
+}
@@ -0,0 +1,473 @@
+// SPDX-License-Identifier: MIT
+
+// Package draw renders the anti-aliased shapes widgets are built from: filled
+// and stroked rectangles, rounded rectangles with per-corner radii, polygons,
+// circles, lines and arrows. Strokes honour their width, and every shape is
+// drawn as colored triangles from one cached white image, through vertex and
+// index buffers that are reused from shape to shape and frame to frame.
+//
+// Curves are flattened into straight segments and outlines are built from
+// quads along each segment, straight into those buffers, so once the buffers
+// have grown drawing allocates nothing.
+//
+// The buffers are package state, so like ebiten's drawing functions the
+// package must only be used from the goroutine that draws.
+package draw
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Point is a position in screen coordinates.
+type Point struct {
+	X, Y float32
+}
+
+// Radii are the corner radii of a rounded rectangle.
+type Radii struct {
+	TopLeft, TopRight, BottomRight, BottomLeft float32
+}
+
+// Uniform returns radii with every corner set to r.
+func Uniform(r float32) Radii {
+	return Radii{r, r, r, r}
+}
+
+// Direction is the way an arrow points.
+type Direction int
+
+const (
+	Up Direction = iota
+	Right
+	Down
+	Left
+)
+
+var (
+	// whiteSubImage is the middle pixel of a 3x3 white image, so sampling it
+	// never bleeds in transparent pixels from outside its edges.
+	whiteSubImage *ebiten.Image
+
+	// Reused between calls to avoid per-frame allocation.
+	vertices []ebiten.Vertex
+	indices  []uint16
+	outline  []Point
+
+	// Vertex colors come from color.Color, which is premultiplied, so ebiten
+	// must not multiply them by alpha a second time. The non-zero rule fills
+	// concave polygons drawn as a fan of triangles, and keeps overlapping
+	// stroke triangles from blending twice.
+	triangleOptions = &ebiten.DrawTrianglesOptions{
+		AntiAlias:      true,
+		FillRule:       ebiten.FillRuleNonZero,
+		ColorScaleMode: ebiten.ColorScaleModePremultipliedAlpha,
+	}
+
+	// drawTriangles is replaced in tests to capture the triangles drawn.
+	drawTriangles = func(dst *ebiten.Image, vs []ebiten.Vertex, is []uint16, src *ebiten.Image, op *ebiten.DrawTrianglesOptions) {
+		dst.DrawTriangles(vs, is, src, op)
+	}
+)
+
+func white() *ebiten.Image {
+	if whiteSubImage == nil {
+		img := ebiten.NewImage(3, 3)
+		img.Fill(color.White)
+		whiteSubImage = img.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+	}
+	return whiteSubImage
+}
+
+// WhiteImage returns the cached 1x1 white image shapes are drawn from, for
+// callers that build their own triangles.
+func WhiteImage() *ebiten.Image {
+	return white()
+}
+
+// Capture runs f and returns copies of the vertices and indices of every shape
+// it draws, instead of drawing them. Images can't be read back before the game
+// loop runs, so tests use it to check what a widget draws.
+func Capture(f func()) ([]ebiten.Vertex, []uint16) {
+	var vs []ebiten.Vertex
+	var is []uint16
+	prev := drawTriangles
+	drawTriangles = func(_ *ebiten.Image, v []ebiten.Vertex, i []uint16, _ *ebiten.Image, _ *ebiten.DrawTrianglesOptions) {
+		base := uint16(len(vs))
+		vs = append(vs, v...)
+		for _, idx := range i {
+			is = append(is, base+idx)
+		}
+	}
+	defer func() { drawTriangles = prev }()
+	f()
+	return vs, is
+}
+
+// flush colors the buffered vertices and draws them.
+func flush(dst *ebiten.Image, col color.Color) {
+	if len(indices) == 0 {
+		return
+	}
+	r, g, b, a := premultiplied(col)
+	for i := range vertices {
+		vertices[i].SrcX, vertices[i].SrcY = 1, 1
+		vertices[i].ColorR = r
+		vertices[i].ColorG = g
+		vertices[i].ColorB = b
+		vertices[i].ColorA = a
+	}
+	drawTriangles(dst, vertices, indices, white(), triangleOptions)
+}
+
+// appendFan adds the polygon through the points to the buffers as a fan of
+// triangles from its first point. Drawn with the non-zero rule, a fan fills
+// concave polygons as well as convex ones.
+func appendFan(points []Point) {
+	base := uint16(len(vertices))
+	for _, pt := range points {
+		vertices = append(vertices, ebiten.Vertex{DstX: pt.X, DstY: pt.Y})
+	}
+	for i := 1; i+1 < len(points); i++ {
+		indices = append(indices, base, base+uint16(i), base+uint16(i+1))
+	}
+}
+
+// appendTriangle adds a triangle to the buffers, wound clockwise whatever
+// the order of its corners. Strokes are built from triangles that overlap at
+// the joins; with one winding the non-zero rule draws the overlap once.
+func appendTriangle(a, b, c Point) {
+	if (b.X-a.X)*(c.Y-a.Y)-(b.Y-a.Y)*(c.X-a.X) < 0 {
+		b, c = c, b
+	}
+	base := uint16(len(vertices))
+	vertices = append(vertices,
+		ebiten.Vertex{DstX: a.X, DstY: a.Y},
+		ebiten.Vertex{DstX: b.X, DstY: b.Y},
+		ebiten.Vertex{DstX: c.X, DstY: c.Y})
+	indices = append(indices, base, base+1, base+2)
+}
+
+// miterLimit is how many times the line width a mitred corner may reach out
+// before it is bevelled instead.
+const miterLimit = 4
+
+// appendStroke adds a line of the given width centred on the path through
+// the points, back to the first point if closed. Corners are mitred, or
+// bevelled when sharper than the miter limit allows, and open ends are cut
+// square at the end points.
+func appendStroke(points []Point, width float32, closed bool) {
+	n := len(points)
+	if n < 2 || width <= 0 {
+		return
+	}
+	half := width / 2
+	edges := n - 1
+	if closed {
+		edges = n
+	}
+	// normal returns the unit normal of the edge from point i, or false for
+	// an edge that doesn't exist or has no length.
+	normal := func(i int) (Point, bool) {
+		if i < 0 || i >= edges {
+			if !closed {
+				return Point{}, false
+			}
+			i = (i + n) % n
+		}
+		p, q := points[i], points[(i+1)%n]
+		dx, dy := q.X-p.X, q.Y-p.Y
+		length := float32(math.Hypot(float64(dx), float64(dy)))
+		if length == 0 {
+			return Point{}, false
+		}
+		return Point{-dy / length, dx / length}, true
+	}
+	// ends returns the corners of the line at point i: where the edge
+	// arriving at it ends and where the edge leaving it starts, on the left
+	// and right of the path. They differ only at a bevelled corner.
+	ends := func(i int) (inL, inR, outL, outR Point) {
+		p := points[i]
+		n0, ok0 := normal(i - 1)
+		n1, ok1 := normal(i)
+		if !ok0 {
+			n0 = n1
+		}
+		if !ok1 {
+			n1 = n0
+		}
+		at := func(o Point) Point { return Point{p.X + o.X, p.Y + o.Y} }
+		mx, my := n0.X+n1.X, n0.Y+n1.Y
+		if m2 := mx*mx + my*my; m2*miterLimit*miterLimit >= 4 {
+			// The miter reaches half the width along both normals.
+			scale := 2 * half / m2
+			l, r := at(Point{mx * scale, my * scale}), at(Point{-mx * scale, -my * scale})
+			return l, r, l, r
+		}
+		inL, inR = at(Point{n0.X * half, n0.Y * half}), at(Point{-n0.X * half, -n0.Y * half})
+		outL, outR = at(Point{n1.X * half, n1.Y * half}), at(Point{-n1.X * half, -n1.Y * half})
+		appendTriangle(p, inL, outL)
+		appendTriangle(p, inR, outR)
+		return inL, inR, outL, outR
+	}
+	firstL, firstR, startL, startR := ends(0)
+	for i := range edges {
+		endL, endR, nextL, nextR := firstL, firstR, startL, startR
+		if j := i + 1; j < n {
+			endL, endR, nextL, nextR = ends(j)
+		}
+		appendTriangle(startL, endL, endR)
+		appendTriangle(startL, endR, startR)
+		startL, startR = nextL, nextR
+	}
+}
+
+// arcTolerance is how far, in pixels, the straight segments a curve is
+// drawn with may stray from it.
+const arcTolerance = 0.1
+
+// appendArc adds points along the arc of radius r around (cx, cy), clockwise
+// on screen from angle a0 to a1, both ends included. A zero radius adds just
+// the centre.
+func appendArc(points []Point, cx, cy, r float32, a0, a1 float64) []Point {
+	if r <= 0 {
+		return append(points, Point{cx, cy})
+	}
+	step := 2 * math.Acos(max(0, 1-arcTolerance/float64(r)))
+	segments := max(1, int(math.Ceil((a1-a0)/step)))
+	for i := range segments + 1 {
+		a := a0 + (a1-a0)*float64(i)/float64(segments)
+		points = appendPoint(points, Point{cx + r*float32(math.Cos(a)), cy + r*float32(math.Sin(a))})
+	}
+	return points
+}
+
+// appendPoint adds pt unless it is where the outline already ends, as where
+// two arcs of a fully rounded side meet. Strokes need every edge to have a
+// length to find its direction.
+func appendPoint(points []Point, pt Point) []Point {
+	if n := len(points); n > 0 && near(points[n-1], pt) {
+		return points
+	}
+	return append(points, pt)
+}
+
+func near(a, b Point) bool {
+	const epsilon = 1e-3
+	return abs(a.X-b.X) < epsilon && abs(a.Y-b.Y) < epsilon
+}
+
+// closeOutline drops the last point of a closed outline if it is the first.
+func closeOutline(points []Point) []Point {
+	if n := len(points); n > 1 && near(points[0], points[n-1]) {
+		return points[:n-1]
+	}
+	return points
+}
+
+// roundedRectPoints appends the outline of a rounded rectangle, clockwise
+// from the end of the top edge. Each radius is clamped to half the width
+// and height.
+func roundedRectPoints(points []Point, x, y, w, h float32, radii Radii) []Point {
+	limit := max(0, min(w, h)/2)
+	tl := clamp(radii.TopLeft, 0, limit)
+	tr := clamp(radii.TopRight, 0, limit)
+	br := clamp(radii.BottomRight, 0, limit)
+	bl := clamp(radii.BottomLeft, 0, limit)
+
+	points = appendArc(points, x+w-tr, y+tr, tr, -math.Pi/2, 0)
+	points = appendArc(points, x+w-br, y+h-br, br, 0, math.Pi/2)
+	points = appendArc(points, x+bl, y+h-bl, bl, math.Pi/2, math.Pi)
+	points = appendArc(points, x+tl, y+tl, tl, math.Pi, 3*math.Pi/2)
+	return closeOutline(points)
+}
+
+// circlePoints appends the outline of a circle, clockwise from its right.
+func circlePoints(points []Point, cx, cy, r float32) []Point {
+	return closeOutline(appendArc(points, cx, cy, r, 0, 2*math.Pi))
+}
+
+// rectPoints returns the corners of a rectangle, clockwise from the top-left.
+func rectPoints(x, y, w, h float32) [4]Point {
+	return [4]Point{{x, y}, {x + w, y}, {x + w, y + h}, {x, y + h}}
+}
+
+// FillRect fills a rectangle.
+func FillRect(dst *ebiten.Image, x, y, w, h float32, col color.Color) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	corners := rectPoints(x, y, w, h)
+	vertices, indices = vertices[:0], indices[:0]
+	appendFan(corners[:])
+	flush(dst, col)
+}
+
+// StrokeRect draws a border of the given width along the inside edge of a
+// rectangle, so thick borders never spill outside it.
+func StrokeRect(dst *ebiten.Image, x, y, w, h, width float32, col color.Color) {
+	if w <= 0 || h <= 0 || width <= 0 {
+		return
+	}
+	// A border wider than half the rectangle fills it.
+	inset := min(width, w/2, h/2)
+	outer := rectPoints(x, y, w, h)
+	inner := rectPoints(x+inset, y+inset, w-2*inset, h-2*inset)
+	vertices, indices = vertices[:0], indices[:0]
+	for i := range 4 {
+		j := (i + 1) % 4
+		side := [4]Point{outer[i], outer[j], inner[j], inner[i]}
+		appendFan(side[:])
+	}
+	flush(dst, col)
+}
+
+// FillRoundedRect fills a rectangle with rounded corners.
+func FillRoundedRect(dst *ebiten.Image, x, y, w, h float32, radii Radii, col color.Color) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	outline = roundedRectPoints(outline[:0], x, y, w, h, radii)
+	vertices, indices = vertices[:0], indices[:0]
+	appendFan(outline)
+	flush(dst, col)
+}
+
+// StrokeRoundedRect draws a border of the given width along the inside edge of
+// a rounded rectangle.
+func StrokeRoundedRect(dst *ebiten.Image, x, y, w, h float32, radii Radii, width float32, col color.Color) {
+	if !insetOutline(x, y, w, h, radii, width) {
+		return
+	}
+	vertices, indices = vertices[:0], indices[:0]
+	appendStroke(outline, width, true)
+	flush(dst, col)
+}
+
+// insetOutline sets outline to the centre line of a border of the given width
+// inside a rounded rectangle, and reports whether there is room for one.
+func insetOutline(x, y, w, h float32, radii Radii, width float32) bool {
+	if width <= 0 || w < width || h < width {
+		return false
+	}
+	inset := width / 2
+	radii = Radii{radii.TopLeft - inset, radii.TopRight - inset, radii.BottomRight - inset, radii.BottomLeft - inset}
+	outline = roundedRectPoints(outline[:0], x+inset, y+inset, w-width, h-width, radii)
+	return true
+}
+
+// FillPolygon fills the polygon through the points, which may be concave.
+func FillPolygon(dst *ebiten.Image, points []Point, col color.Color) {
+	if len(points) < 3 {
+		return
+	}
+	vertices, indices = vertices[:0], indices[:0]
+	appendFan(points)
+	flush(dst, col)
+}
+
+// StrokePolygon draws a closed outline of the given width through the points,
+// centred on the edges.
+func StrokePolygon(dst *ebiten.Image, points []Point, width float32, col color.Color) {
+	if len(points) < 2 {
+		return
+	}
+	vertices, indices = vertices[:0], indices[:0]
+	appendStroke(points, width, true)
+	flush(dst, col)
+}
+
+// Polyline draws an open line of the given width through the points, with
+// mitred joins.
+func Polyline(dst *ebiten.Image, points []Point, width float32, col color.Color) {
+	if len(points) < 2 {
+		return
+	}
+	vertices, indices = vertices[:0], indices[:0]
+	appendStroke(points, width, false)
+	flush(dst, col)
+}
+
+// Line draws a straight line of the given width, with square ends flush with
+// its end points.
+func Line(dst *ebiten.Image, x0, y0, x1, y1, width float32, col color.Color) {
+	dx, dy := x1-x0, y1-y0
+	length := float32(math.Hypot(float64(dx), float64(dy)))
+	if length == 0 || width <= 0 {
+		return
+	}
+	// Offset both ends by half the width along the line's normal.
+	nx, ny := -dy/length*width/2, dx/length*width/2
+	quad := [4]Point{{x0 + nx, y0 + ny}, {x1 + nx, y1 + ny}, {x1 - nx, y1 - ny}, {x0 - nx, y0 - ny}}
+	vertices, indices = vertices[:0], indices[:0]
+	appendFan(quad[:])
+	flush(dst, col)
+}
+
+// FillCircle fills a circle.
+func FillCircle(dst *ebiten.Image, cx, cy, r float32, col color.Color) {
+	if r <= 0 {
+		return
+	}
+	outline = circlePoints(outline[:0], cx, cy, r)
+	vertices, indices = vertices[:0], indices[:0]
+	appendFan(outline)
+	flush(dst, col)
+}
+
+// StrokeCircle draws a circle outline of the given width, centred on the radius.
+func StrokeCircle(dst *ebiten.Image, cx, cy, r, width float32, col color.Color) {
+	if r <= 0 {
+		return
+	}
+	outline = circlePoints(outline[:0], cx, cy, r)
+	vertices, indices = vertices[:0], indices[:0]
+	appendStroke(outline, width, true)
+	flush(dst, col)
+}
+
+// arrowPoints fills buf with the corners of a triangle filling the box and
+// pointing in dir: one end of the base, the tip, the other end of the base.
+func arrowPoints(buf *[3]Point, x, y, w, h float32, dir Direction) []Point {
+	switch dir {
+	case Up:
+		*buf = [3]Point{{x, y + h}, {x + w/2, y}, {x + w, y + h}}
+	case Right:
+		*buf = [3]Point{{x, y}, {x + w, y + h/2}, {x, y + h}}
+	case Down:
+		*buf = [3]Point{{x, y}, {x + w/2, y + h}, {x + w, y}}
+	default:
+		*buf = [3]Point{{x + w, y}, {x, y + h/2}, {x + w, y + h}}
+	}
+	return buf[:]
+}
+
+// FillArrow fills a triangular arrow head filling the box and pointing in dir,
+// as used for dropdowns, spinners and scroll buttons.
+func FillArrow(dst *ebiten.Image, x, y, w, h float32, dir Direction, col color.Color) {
+	var buf [3]Point
+	FillPolygon(dst, arrowPoints(&buf, x, y, w, h, dir), col)
+}
+
+// Chevron draws an open arrow head (a "v" shape) of the given line width
+// filling the box and pointing in dir.
+func Chevron(dst *ebiten.Image, x, y, w, h float32, dir Direction, width float32, col color.Color) {
+	var buf [3]Point
+	vertices, indices = vertices[:0], indices[:0]
+	appendStroke(arrowPoints(&buf, x, y, w, h, dir), width, false)
+	flush(dst, col)
+}
+
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func clamp(v, lo, hi float32) float32 {
+	return max(lo, min(v, hi))
+}
@@ -0,0 +1,354 @@
+package draw_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/draw"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func TestFillUsesColor(t *testing.T) {
+	col := color.RGBA{R: 255, G: 102, B: 0, A: 255}
+	vertices, indices := draw.Capture(func() {
+		draw.FillRoundedRect(nil, 10, 10, 100, 40, draw.Uniform(8), col)
+	})
+	if len(vertices) == 0 || len(indices) == 0 {
+		t.Fatal("no triangles for the fill")
+	}
+	for _, v := range vertices {
+		if v.ColorR != 1 || v.ColorG != 0.4 || v.ColorB != 0 || v.ColorA != 1 {
+			t.Fatalf("vertex color %v %v %v %v, want the fill color", v.ColorR, v.ColorG, v.ColorB, v.ColorA)
+		}
+	}
+}
+
+// Images can't be read back before the game loop runs, so the test works out
+// the pixel a translucent fill leaves on a transparent image the way ebiten
+// does: vertex colors are multiplied by their alpha unless the options say
+// they are premultiplied already.
+func TestTranslucentFillKeepsItsColor(t *testing.T) {
+	col := color.RGBA{R: 128, G: 0, B: 0, A: 128}
+	vertices, _ := draw.Capture(func() {
+		draw.FillRect(nil, 10, 10, 100, 40, col)
+	})
+	op := draw.TriangleOptions()
+	for _, v := range vertices {
+		r, a := v.ColorR, v.ColorA
+		if op.ColorScaleMode == ebiten.ColorScaleModeStraightAlpha {
+			r *= a
+		}
+		got := color.RGBA{R: uint8(r*255 + 0.5), A: uint8(a*255 + 0.5)}
+		if got != col {
+			t.Fatalf("translucent fill gives pixel %v, want %v", got, col)
+		}
+	}
+}
+
+func TestStrokeRoundedRectStaysInsideTheBounds(t *testing.T) {
+	const x, y, w, h, radius, width = 10, 10, 100, 40, 8, 3
+	vertices, _ := draw.Capture(func() {
+		draw.StrokeRoundedRect(nil, x, y, w, h, draw.Uniform(radius), width, color.Black)
+	})
+	if len(vertices) == 0 {
+		t.Fatal("no triangles for the outline")
+	}
+	innerEdge := false
+	for _, v := range vertices {
+		if v.DstX < x-0.01 || v.DstX > x+w+0.01 || v.DstY < y-0.01 || v.DstY > y+h+0.01 {
+			t.Fatalf("outline vertex (%v, %v) outside the bounds", v.DstX, v.DstY)
+		}
+		// Unlike a filled shape, a stroke has vertices on its inner edge,
+		// here where the straight top edge meets the corners.
+		if v.DstX > x+radius-0.01 && v.DstX < x+w-radius+0.01 && abs(v.DstY-(y+width)) < 0.01 {
+			innerEdge = true
+		}
+	}
+	if !innerEdge {
+		t.Fatal("outline has no inner edge along the top")
+	}
+}
+
+func TestStrokeRectHonoursWidth(t *testing.T) {
+	const x, y, w, h = 0, 0, 50, 20
+	for _, width := range []float32{1, 4} {
+		vertices, _ := draw.Capture(func() {
+			draw.StrokeRect(nil, x, y, w, h, width, color.Black)
+		})
+		var minY, maxInnerY float32 = h, 0
+		for _, v := range vertices {
+			minY = min(minY, v.DstY)
+			// The inner edge of the top border.
+			if v.DstY < h/2 {
+				maxInnerY = max(maxInnerY, v.DstY)
+			}
+		}
+		if abs(minY) > 0.01 || abs(maxInnerY-width) > 0.01 {
+			t.Errorf("width %v: top border spans %v to %v, want 0 to %v", width, minY, maxInnerY, width)
+		}
+	}
+}
+
+func TestFillArrowPointsInDirection(t *testing.T) {
+	tests := []struct {
+		dir        draw.Direction
+		tipX, tipY float32
+	}{
+		{draw.Up, 10, 0},
+		{draw.Right, 20, 10},
+		{draw.Down, 10, 20},
+		{draw.Left, 0, 10},
+	}
+	for _, tt := range tests {
+		vertices, _ := draw.Capture(func() {
+			draw.FillArrow(nil, 0, 0, 20, 20, tt.dir, color.Black)
+		})
+		found := false
+		for _, v := range vertices {
+			if abs(v.DstX-tt.tipX) < 0.01 && abs(v.DstY-tt.tipY) < 0.01 {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("direction %v: no vertex at the tip (%v, %v)", tt.dir, tt.tipX, tt.tipY)
+		}
+	}
+}
+
+func TestDegenerateShapesDrawNothing(t *testing.T) {
+	vertices, _ := draw.Capture(func() {
+		draw.FillPolygon(nil, []draw.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}, color.Black)
+		draw.StrokePolygon(nil, []draw.Point{{X: 0, Y: 0}}, 1, color.Black)
+	})
+	if len(vertices) != 0 {
+		t.Fatalf("drew %d vertices for degenerate polygons", len(vertices))
+	}
+}
+
+func TestGradientBlendsAcrossTheBounds(t *testing.T) {
+	g := draw.Gradient{From: color.White, To: color.Black, Dir: draw.Down}
+	vertices, _ := draw.Capture(func() {
+		draw.FillRoundedRectGradient(nil, 0, 10, 40, 20, draw.Radii{}, g)
+	})
+	if len(vertices) == 0 {
+		t.Fatal("no triangles for the gradient")
+	}
+	for _, v := range vertices {
+		want := 1 - (v.DstY-10)/20
+		if abs(v.ColorR-want) > 0.01 || v.ColorA != 1 {
+			t.Fatalf("vertex at y=%v has red %v alpha %v, want red %v alpha 1", v.DstY, v.ColorR, v.ColorA, want)
+		}
+	}
+
+	g.Dir = draw.Left
+	vertices, _ = draw.Capture(func() {
+		draw.FillPolygonGradient(nil, []draw.Point{{X: 0, Y: 0}, {X: 40, Y: 0}, {X: 20, Y: 30}}, g)
+	})
+	for _, v := range vertices {
+		if want := v.DstX / 40; abs(v.ColorR-want) > 0.01 {
+			t.Fatalf("vertex at x=%v has red %v, want %v", v.DstX, v.ColorR, want)
+		}
+	}
+}
+
+func TestShadowFadesOverTheBlur(t *testing.T) {
+	const x, y, w, h, blur = 20, 20, 60, 30, 8
+	col := color.RGBA{A: 128}
+	vertices, _ := draw.Capture(func() {
+		draw.Shadow(nil, x, y, w, h, draw.Uniform(4), blur, col)
+	})
+	if len(vertices) == 0 {
+		t.Fatal("no triangles for the shadow")
+	}
+	minX := float32(x)
+	for _, v := range vertices {
+		minX = min(minX, v.DstX)
+		if v.ColorA >= 128.0/255 {
+			t.Fatalf("a single layer has alpha %v, want less than the shadow's", v.ColorA)
+		}
+	}
+	if minX >= x || minX < x-blur/2-0.01 {
+		t.Fatalf("shadow starts at x=%v, want just left of %v by at most %v", minX, x, blur/2)
+	}
+
+	hard, _ := draw.Capture(func() {
+		draw.Shadow(nil, x, y, w, h, draw.Uniform(4), 0, col)
+	})
+	for _, v := range hard {
+		if v.DstX < x-0.01 || abs(v.ColorA-128.0/255) > 0.01 {
+			t.Fatalf("hard shadow vertex at x=%v alpha %v, want inside the bounds at the shadow's alpha", v.DstX, v.ColorA)
+		}
+	}
+}
+
+func TestNineSliceKeepsCornersAndStretchesTheMiddle(t *testing.T) {
+	atlas := ebiten.NewImage(64, 64)
+	img := atlas.SubImage(image.Rect(16, 16, 40, 40)).(*ebiten.Image) // 24x24
+	n := draw.NewNineSlice(img, 4, 6, 8, 2)
+
+	vertices, indices := draw.Capture(func() {
+		n.Draw(nil, 100, 50, 200, 80)
+	})
+	if len(vertices) != 16 || len(indices) != 54 {
+		t.Fatalf("got %d vertices and %d indices, want a 4x4 grid of 9 quads", len(vertices), len(indices))
+	}
+	wantDstX := []float32{100, 104, 292, 300}
+	wantSrcX := []float32{16, 20, 32, 40}
+	wantDstY := []float32{50, 56, 128, 130}
+	wantSrcY := []float32{16, 22, 38, 40}
+	for i, v := range vertices {
+		row, col := i/4, i%4
+		if v.DstX != wantDstX[col] || v.SrcX != wantSrcX[col] || v.DstY != wantDstY[row] || v.SrcY != wantSrcY[row] {
+			t.Errorf("vertex %d maps source (%v, %v) to (%v, %v), want (%v, %v) to (%v, %v)",
+				i, v.SrcX, v.SrcY, v.DstX, v.DstY, wantSrcX[col], wantSrcY[row], wantDstX[col], wantDstY[row])
+		}
+	}
+
+	// Too narrow for both borders: they shrink in proportion.
+	vertices, _ = draw.Capture(func() {
+		n.Draw(nil, 0, 0, 6, 80)
+	})
+	if vertices[1].DstX != 2 || vertices[2].DstX != 2 {
+		t.Fatalf("narrow borders end at %v and start at %v, want both at 2", vertices[1].DstX, vertices[2].DstX)
+	}
+}
+
+func TestPolylineIsOpen(t *testing.T) {
+	// A closed triangle would have a stroke along its third side, through (10, 0).
+	vertices, _ := draw.Capture(func() {
+		draw.Polyline(nil, []draw.Point{{X: 0, Y: 0}, {X: 10, Y: 20}, {X: 20, Y: 0}}, 2, color.Black)
+	})
+	if len(vertices) == 0 {
+		t.Fatal("no triangles for the polyline")
+	}
+	for _, v := range vertices {
+		if v.DstX > 5 && v.DstX < 15 && v.DstY < 2 {
+			t.Fatalf("vertex (%v, %v) joins the ends of the polyline", v.DstX, v.DstY)
+		}
+	}
+}
+
+func TestShapesDoNotAllocate(t *testing.T) {
+	// A color.Color variable, so only the shapes are measured and not
+	// boxing the color into an interface.
+	var col color.Color = color.RGBA{R: 255, A: 255}
+	g := draw.Gradient{From: col, To: col}
+	points := []draw.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 5, Y: 4}, {X: 5, Y: 10}}
+	draw.Discard(func() {
+		allocs := testing.AllocsPerRun(100, func() {
+			draw.FillRect(nil, 0, 0, 10, 10, col)
+			draw.StrokeRect(nil, 0, 0, 10, 10, 2, col)
+			draw.FillRoundedRect(nil, 0, 0, 100, 40, draw.Uniform(8), col)
+			draw.StrokeRoundedRect(nil, 0, 0, 100, 40, draw.Uniform(8), 2, col)
+			draw.FillRoundedRectGradient(nil, 0, 0, 100, 40, draw.Uniform(8), g)
+			draw.StrokeRoundedRectGradient(nil, 0, 0, 100, 40, draw.Uniform(8), 1, g)
+			draw.FillCircle(nil, 50, 50, 20, col)
+			draw.StrokeCircle(nil, 50, 50, 20, 2, col)
+			draw.Line(nil, 0, 0, 10, 10, 2, col)
+			draw.FillPolygon(nil, points, col)
+			draw.StrokePolygon(nil, points, 2, col)
+			draw.Polyline(nil, points, 2, col)
+			draw.FillArrow(nil, 0, 0, 10, 10, draw.Up, col)
+			draw.Chevron(nil, 0, 0, 10, 10, draw.Down, 2, col)
+		})
+		if allocs != 0 {
+			t.Fatalf("%v allocations drawing shapes, want none", allocs)
+		}
+	})
+}
+
+// covers reports whether any of the triangles contains the point.
+func covers(vertices []ebiten.Vertex, indices []uint16, x, y float32) bool {
+	side := func(a, b ebiten.Vertex) float32 {
+		return (b.DstX-a.DstX)*(y-a.DstY) - (b.DstY-a.DstY)*(x-a.DstX)
+	}
+	for i := 0; i+2 < len(indices); i += 3 {
+		a, b, c := vertices[indices[i]], vertices[indices[i+1]], vertices[indices[i+2]]
+		d0, d1, d2 := side(a, b), side(b, c), side(c, a)
+		if (d0 >= 0 && d1 >= 0 && d2 >= 0) || (d0 <= 0 && d1 <= 0 && d2 <= 0) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCurvesCoverTheirShape(t *testing.T) {
+	tests := []struct {
+		name    string
+		draw    func()
+		in, out []draw.Point
+	}{
+		{
+			name: "rounded rect",
+			draw: func() { draw.FillRoundedRect(nil, 10, 10, 100, 40, draw.Uniform(8), color.Black) },
+			in:   []draw.Point{{X: 60, Y: 30}, {X: 12.5, Y: 12.5}, {X: 60, Y: 10.5}},
+			out:  []draw.Point{{X: 10.5, Y: 10.5}, {X: 109.5, Y: 49.5}},
+		},
+		{
+			name: "rounded outline",
+			draw: func() { draw.StrokeRoundedRect(nil, 10, 10, 100, 40, draw.Uniform(8), 3, color.Black) },
+			in:   []draw.Point{{X: 60, Y: 11.5}, {X: 60, Y: 48.5}, {X: 11.5, Y: 30}, {X: 13, Y: 13}},
+			out:  []draw.Point{{X: 60, Y: 14}, {X: 60, Y: 30}, {X: 10.5, Y: 10.5}},
+		},
+		{
+			name: "circle",
+			draw: func() { draw.FillCircle(nil, 50, 50, 20, color.Black) },
+			in:   []draw.Point{{X: 50, Y: 50}, {X: 69.5, Y: 50}, {X: 50, Y: 30.5}, {X: 63, Y: 63}},
+			out:  []draw.Point{{X: 65, Y: 65}, {X: 70.5, Y: 50}},
+		},
+		{
+			name: "circle outline",
+			draw: func() { draw.StrokeCircle(nil, 50, 50, 20, 4, color.Black) },
+			in:   []draw.Point{{X: 70, Y: 50}, {X: 50, Y: 31}, {X: 64, Y: 64}},
+			out:  []draw.Point{{X: 50, Y: 50}, {X: 50, Y: 27.5}, {X: 50, Y: 72.5}},
+		},
+	}
+	for _, tt := range tests {
+		vertices, indices := draw.Capture(tt.draw)
+		for _, pt := range tt.in {
+			if !covers(vertices, indices, pt.X, pt.Y) {
+				t.Errorf("%s: (%v, %v) not covered", tt.name, pt.X, pt.Y)
+			}
+		}
+		for _, pt := range tt.out {
+			if covers(vertices, indices, pt.X, pt.Y) {
+				t.Errorf("%s: (%v, %v) covered", tt.name, pt.X, pt.Y)
+			}
+		}
+	}
+}
+
+func TestSharpCornersAreBevelled(t *testing.T) {
+	// A spike whose miter would reach 10 widths out from the tip at (100, 5).
+	points := []draw.Point{{X: 0, Y: 0}, {X: 100, Y: 5}, {X: 0, Y: 10}}
+	vertices, _ := draw.Capture(func() {
+		draw.Polyline(nil, points, 2, color.Black)
+	})
+	for _, v := range vertices {
+		if v.DstX > 102 {
+			t.Fatalf("vertex (%v, %v) reaches past the tip", v.DstX, v.DstY)
+		}
+	}
+}
+
+func TestLineIsWidthAcross(t *testing.T) {
+	vertices, _ := draw.Capture(func() {
+		draw.Line(nil, 0, 10, 20, 10, 4, color.Black)
+	})
+	if len(vertices) != 4 {
+		t.Fatalf("got %d vertices, want a single quad", len(vertices))
+	}
+	for _, v := range vertices {
+		if v.DstX != 0 && v.DstX != 20 || abs(abs(v.DstY-10)-2) > 0.01 {
+			t.Fatalf("vertex (%v, %v), want the ends offset 2px either side of y=10", v.DstX, v.DstY)
+		}
+	}
+}
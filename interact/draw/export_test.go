@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: MIT
+package draw
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// TriangleOptions returns the options shapes are drawn with.
+func TriangleOptions() *ebiten.DrawTrianglesOptions {
+	return triangleOptions
+}
+
+// Discard runs f without drawing the shapes it builds.
+func Discard(f func()) {
+	prev := drawTriangles
+	drawTriangles = func(*ebiten.Image, []ebiten.Vertex, []uint16, *ebiten.Image, *ebiten.DrawTrianglesOptions) {}
+	defer func() { drawTriangles = prev }()
+	f()
+}
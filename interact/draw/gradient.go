@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+package draw
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Gradient is a linear blend from one color to another across the bounding box
+// of a shape. Dir is the way the blend runs: Down starts with From at the top
+// and ends with To at the bottom, Right runs from left to right.
+type Gradient struct {
+	From, To color.Color
+	Dir      Direction
+}
+
+// FillRoundedRectGradient fills a rectangle with rounded corners with a gradient.
+func FillRoundedRectGradient(dst *ebiten.Image, x, y, w, h float32, radii Radii, g Gradient) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	outline = roundedRectPoints(outline[:0], x, y, w, h, radii)
+	vertices, indices = vertices[:0], indices[:0]
+	appendFan(outline)
+	flushGradient(dst, g, x, y, w, h)
+}
+
+// StrokeRoundedRectGradient is StrokeRoundedRect with a gradient along the
+// border, such as a highlight that fades out towards the bottom.
+func StrokeRoundedRectGradient(dst *ebiten.Image, x, y, w, h float32, radii Radii, width float32, g Gradient) {
+	if !insetOutline(x, y, w, h, radii, width) {
+		return
+	}
+	vertices, indices = vertices[:0], indices[:0]
+	appendStroke(outline, width, true)
+	flushGradient(dst, g, x, y, w, h)
+}
+
+// FillPolygonGradient fills the polygon through the points with a gradient
+// across their bounding box.
+func FillPolygonGradient(dst *ebiten.Image, points []Point, g Gradient) {
+	if len(points) < 3 {
+		return
+	}
+	minX, minY := points[0].X, points[0].Y
+	maxX, maxY := minX, minY
+	for _, pt := range points[1:] {
+		minX, minY = min(minX, pt.X), min(minY, pt.Y)
+		maxX, maxY = max(maxX, pt.X), max(maxY, pt.Y)
+	}
+	vertices, indices = vertices[:0], indices[:0]
+	appendFan(points)
+	flushGradient(dst, g, minX, minY, maxX-minX, maxY-minY)
+}
+
+// flushGradient colors each buffered vertex by where it lies in the box and
+// draws them. Triangles interpolate vertex colors linearly, so a linear
+// gradient comes out exact whatever the tessellation.
+func flushGradient(dst *ebiten.Image, g Gradient, x, y, w, h float32) {
+	if len(indices) == 0 {
+		return
+	}
+	fr, fg, fb, fa := premultiplied(g.From)
+	tr, tg, tb, ta := premultiplied(g.To)
+	for i := range vertices {
+		v := &vertices[i]
+		var t float32
+		switch g.Dir {
+		case Up:
+			t = 1 - ratio(v.DstY-y, h)
+		case Right:
+			t = ratio(v.DstX-x, w)
+		case Left:
+			t = 1 - ratio(v.DstX-x, w)
+		default:
+			t = ratio(v.DstY-y, h)
+		}
+		v.SrcX, v.SrcY = 1, 1
+		v.ColorR = fr + (tr-fr)*t
+		v.ColorG = fg + (tg-fg)*t
+		v.ColorB = fb + (tb-fb)*t
+		v.ColorA = fa + (ta-fa)*t
+	}
+	drawTriangles(dst, vertices, indices, white(), triangleOptions)
+}
+
+// ratio returns d/length clamped to [0, 1], or 0 for an empty length.
+func ratio(d, length float32) float32 {
+	if length <= 0 {
+		return 0
+	}
+	return clamp(d/length, 0, 1)
+}
+
+// premultiplied returns the premultiplied components of col scaled to [0, 1],
+// as vertex colors expect.
+func premultiplied(col color.Color) (r, g, b, a float32) {
+	cr, cg, cb, ca := col.RGBA()
+	return float32(cr) / 0xffff, float32(cg) / 0xffff, float32(cb) / 0xffff, float32(ca) / 0xffff
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+package draw
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// NineSlice is an image cut into a 3x3 grid by four insets, for skinning
+// widgets with artist-made frames. When drawn at any size the corners keep
+// their size, the edges stretch along their length and the centre stretches
+// both ways, so a small image can frame a widget of any size without its
+// border blurring or thickening.
+//
+// Image may be a sub-image of a texture atlas.
+type NineSlice struct {
+	Image                    *ebiten.Image
+	Left, Top, Right, Bottom int // Widths of the borders, in source pixels
+}
+
+// NewNineSlice returns a nine-slice of img with the given border widths.
+func NewNineSlice(img *ebiten.Image, left, top, right, bottom int) *NineSlice {
+	return &NineSlice{Image: img, Left: left, Top: top, Right: right, Bottom: bottom}
+}
+
+var nineSliceOptions = &ebiten.DrawTrianglesOptions{}
+
+// Draw stretches the nine-slice over a rectangle. A rectangle narrower or
+// shorter than the borders shrinks them proportionally to fit.
+func (n *NineSlice) Draw(dst *ebiten.Image, x, y, w, h float32) {
+	if n == nil || n.Image == nil || w <= 0 || h <= 0 {
+		return
+	}
+	b := n.Image.Bounds()
+	left, right := fitBorders(float32(n.Left), float32(n.Right), w)
+	top, bottom := fitBorders(float32(n.Top), float32(n.Bottom), h)
+
+	dstX := [4]float32{x, x + left, x + w - right, x + w}
+	dstY := [4]float32{y, y + top, y + h - bottom, y + h}
+	srcX := [4]float32{float32(b.Min.X), float32(b.Min.X + n.Left), float32(b.Max.X - n.Right), float32(b.Max.X)}
+	srcY := [4]float32{float32(b.Min.Y), float32(b.Min.Y + n.Top), float32(b.Max.Y - n.Bottom), float32(b.Max.Y)}
+
+	vertices, indices = vertices[:0], indices[:0]
+	for row := range 4 {
+		for col := range 4 {
+			vertices = append(vertices, ebiten.Vertex{
+				DstX: dstX[col], DstY: dstY[row],
+				SrcX: srcX[col], SrcY: srcY[row],
+				ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1,
+			})
+		}
+	}
+	for row := range uint16(3) {
+		for col := range uint16(3) {
+			i := row*4 + col
+			indices = append(indices, i, i+1, i+4, i+1, i+5, i+4)
+		}
+	}
+	drawTriangles(dst, vertices, indices, n.Image, nineSliceOptions)
+}
+
+// fitBorders scales a pair of border widths down to fit a length if they
+// would overlap.
+func fitBorders(a, b, length float32) (float32, float32) {
+	if a+b <= length || a+b <= 0 {
+		return a, b
+	}
+	scale := length / (a + b)
+	return a * scale, b * scale
+}
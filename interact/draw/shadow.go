@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: MIT
+package draw
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// maxShadowLayers caps how many layers a blurred shadow is built from.
+const maxShadowLayers = 8
+
+// Shadow draws the shadow of a rounded rectangle in col. A blur of zero or
+// less gives a hard-edged shadow; otherwise the edge fades out over blur
+// pixels, centred on the rectangle's edge. The blur is approximated by
+// stacking translucent rounded rectangles of increasing size, about one per
+// two pixels of blur, so it stays cheap enough to draw every frame.
+//
+// Offset the rectangle to cast the shadow: the shadow of a widget at x, y
+// falling 4 pixels down is drawn at x, y+4.
+func Shadow(dst *ebiten.Image, x, y, w, h float32, radii Radii, blur float32, col color.Color) {
+	if blur <= 0 {
+		FillRoundedRect(dst, x, y, w, h, radii, col)
+		return
+	}
+	layers := min(max(int(blur/2), 1), maxShadowLayers)
+
+	// Each layer is drawn with the alpha that makes all of them stacked
+	// together reach the shadow's own alpha at its centre.
+	r, g, b, a := premultiplied(col)
+	layerA := 1 - float32(math.Pow(float64(1-a), 1/float64(layers)))
+	scale := float32(1)
+	if a > 0 {
+		scale = layerA / a
+	}
+	layer := color.RGBA64{
+		R: uint16(r * scale * 0xffff),
+		G: uint16(g * scale * 0xffff),
+		B: uint16(b * scale * 0xffff),
+		A: uint16(layerA * 0xffff),
+	}
+
+	for i := range layers {
+		// Grow from blur/2 inside the edge to blur/2 outside it.
+		grow := blur*(float32(i)+0.5)/float32(layers) - blur/2
+		grown := Radii{radii.TopLeft + grow, radii.TopRight + grow, radii.BottomRight + grow, radii.BottomLeft + grow}
+		FillRoundedRect(dst, x-grow, y-grow, w+2*grow, h+2*grow, grown, layer)
+	}
+}
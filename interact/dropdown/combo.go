@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+package dropdown
+
+import "strings"
+
+// updateField lays out and updates the text field of an editable dropdown,
+// and filters the list whenever the user changes its text.
+func (d *Dropdown) updateField() {
+	f := d.Field
+	f.Bounds.X, f.Bounds.Y = d.Bounds.X, d.Bounds.Y
+	f.Bounds.W, f.Bounds.H = d.Bounds.W-d.arrowWidth(), d.Bounds.H
+	if f.FontFace == nil {
+		f.FontFace, f.FontSize = d.FontFace, d.FontSize
+	}
+	f.Input, f.Clock, f.KeyRepeat = d.Input, d.Clock, d.KeyRepeat
+	f.SetUneditable(!d.Enabled || d.Uneditable)
+	f.Update()
+
+	if f.Text == d.filter {
+		return
+	}
+	d.filter = f.Text
+	if !f.IsActive {
+		return
+	}
+	d.applyFilter()
+	d.IsOpen = len(d.visible) > 0
+	d.highlighted = -1
+	if d.filter != "" && len(d.visible) > 0 {
+		d.highlighted = 0
+	}
+	d.scroll = 0
+}
+
+// applyFilter lists the items containing the field's text, ignoring case.
+func (d *Dropdown) applyFilter() {
+	needle := strings.ToLower(d.filter)
+	d.visible = d.visible[:0]
+	for i, item := range d.Items {
+		if strings.Contains(strings.ToLower(item), needle) {
+			d.visible = append(d.visible, i)
+		}
+	}
+}
+
+// acceptText selects the item matching the typed text, ignoring case, or
+// clears the selection and reports the text with an index of -1.
+func (d *Dropdown) acceptText() {
+	d.Close()
+	for i, item := range d.Items {
+		if strings.EqualFold(item, d.Field.Text) {
+			d.choose(i)
+			return
+		}
+	}
+	if d.selected == -1 && d.Field.Text == "" {
+		return
+	}
+	d.selected = -1
+	if d.OnChange != nil {
+		d.OnChange(-1, d.Field.Text)
+	}
+}
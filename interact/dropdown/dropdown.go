@@ -0,0 +1,577 @@
+// SPDX-License-Identifier: MIT
+
+// Package dropdown provides a button that opens a list to choose an item
+// from, optionally with a text field to type into and filter the list by.
+package dropdown
+
+import (
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/button"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/clock"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/draw"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/paint"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/overlay"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/textfield"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+// DefaultMaxVisible is how many items an open list shows before it scrolls.
+const DefaultMaxVisible = 8
+
+// fieldMaxLength is the MaxLength of an editable dropdown's text field.
+const fieldMaxLength = 256
+
+// typeAheadTimeout is how long, in seconds, typed characters keep adding to
+// the prefix being searched for before a new search starts.
+const typeAheadTimeout = 1.0
+
+// Dropdown shows the selected item like a Button and, when clicked, opens a
+// list of the items below it. The list is drawn with overlay.Defer, so it
+// appears above widgets drawn later, and the widgets it covers ignore the
+// mouse while it is open.
+//
+// Clicking an item selects it; clicking anywhere else or pressing Escape
+// closes the list. After a click the dropdown is active: the arrow keys,
+// Page Up, Page Down, Home and End move through the items, Enter chooses one
+// and typing jumps to the next item starting with the typed text. The mouse
+// wheel scrolls long lists.
+//
+// An editable dropdown (a combo box) has a TextField in place of the label.
+// Typing into it opens the list showing only the items containing the text.
+type Dropdown struct {
+	*button.Button
+	Items          []string
+	Placeholder    string // Shown while nothing is selected
+	MaxVisible     int    // Items shown before the list scrolls; zero or less uses DefaultMaxVisible
+	ItemHeight     float32
+	PopupColor     color.RGBA
+	HighlightColor color.RGBA // Background of the item under the mouse or keyboard
+	IsOpen         bool
+	IsActive       bool                 // Keys move through the items
+	Field          *textfield.TextField // The text field of an editable dropdown; nil otherwise
+	KeyRepeat      *input.KeyRepeat     // Timing of held keys; nil uses input.DefaultKeyRepeat()
+
+	// OnChange is called from Update when the user chooses a different item.
+	// In an editable dropdown, text that matches no item is reported with an
+	// index of -1 when Enter is pressed.
+	OnChange func(index int, item string)
+
+	selected    int
+	visible     []int // Indices of the items in the open list, after filtering
+	highlighted int   // Position in visible of the highlighted item, or -1
+	scroll      int   // Position in visible of the first item shown
+	pressedRow  int   // Position in visible of the item the mouse went down on, or -1
+	filter      string
+	typed       string
+	typedTimer  float32
+	wheel       float64 // Wheel movement not yet scrolled, in items
+	repeater    input.Repeater
+}
+
+// New creates a dropdown of items with nothing selected.
+func New(x, y, width, height float32, items ...string) *Dropdown {
+	d := &Dropdown{
+		Button:         button.NewButton(x, y, width, height, ""),
+		Items:          items,
+		MaxVisible:     DefaultMaxVisible,
+		ItemHeight:     height,
+		PopupColor:     color.RGBA{R: 255, G: 255, B: 255, A: 255}, // White
+		HighlightColor: color.RGBA{R: 173, G: 214, B: 255, A: 255}, // Light blue
+		selected:       -1,
+		highlighted:    -1,
+		pressedRow:     -1,
+	}
+	d.SetRoundedCorners(false)
+	return d
+}
+
+// NewEditable creates an editable dropdown, or combo box, of items.
+func NewEditable(x, y, width, height float32, items ...string) *Dropdown {
+	d := New(x, y, width, height, items...)
+	d.SetEditable(true)
+	return d
+}
+
+// SetEditable gives the dropdown a text field to type into, or takes it away.
+func (d *Dropdown) SetEditable(editable bool) {
+	if !editable {
+		d.Field = nil
+		return
+	}
+	if d.Field == nil {
+		d.Field = textfield.NewTextField(d.Bounds.X, d.Bounds.Y, d.Bounds.W, d.Bounds.H, fieldMaxLength)
+		d.Field.SetPlaceholder(d.Placeholder)
+		if item, ok := d.SelectedItem(); ok {
+			d.Field.SetValue(item)
+		}
+		d.filter = d.Field.Text
+	}
+}
+
+// SetItems replaces the items, keeping the selection if the selected item is
+// still among them.
+func (d *Dropdown) SetItems(items ...string) {
+	item, ok := d.SelectedItem()
+	d.Items = items
+	d.selected = -1
+	if ok {
+		d.SelectItem(item)
+	}
+	d.Close()
+}
+
+// SetPlaceholder sets the text shown while nothing is selected.
+func (d *Dropdown) SetPlaceholder(placeholder string) {
+	d.Placeholder = placeholder
+	if d.Field != nil {
+		d.Field.SetPlaceholder(placeholder)
+	}
+}
+
+// SetMaxVisible sets how many items the open list shows before it scrolls.
+func (d *Dropdown) SetMaxVisible(n int) {
+	d.MaxVisible = n
+}
+
+// SetKeyRepeat sets the timing of held keys. Passing nil uses input.DefaultKeyRepeat().
+func (d *Dropdown) SetKeyRepeat(repeat *input.KeyRepeat) {
+	d.KeyRepeat = repeat
+}
+
+// Selected returns the index of the selected item, or -1 if none is.
+func (d *Dropdown) Selected() int {
+	if d.selected >= len(d.Items) {
+		return -1
+	}
+	return d.selected
+}
+
+// SelectedItem returns the selected item, if any.
+func (d *Dropdown) SelectedItem() (string, bool) {
+	i := d.Selected()
+	if i < 0 {
+		return "", false
+	}
+	return d.Items[i], true
+}
+
+// Text returns the text shown: the selected item, or what has been typed into
+// an editable dropdown.
+func (d *Dropdown) Text() string {
+	if d.Field != nil {
+		return d.Field.Text
+	}
+	item, _ := d.SelectedItem()
+	return item
+}
+
+// SetSelected selects the item at index without calling OnChange. An index
+// out of range clears the selection.
+func (d *Dropdown) SetSelected(index int) {
+	if index < 0 || index >= len(d.Items) {
+		index = -1
+	}
+	d.selected = index
+	if d.Field != nil {
+		item, _ := d.SelectedItem()
+		d.Field.SetValue(item)
+		d.filter = d.Field.Text
+	}
+}
+
+// SelectItem selects the first item equal to item without calling OnChange,
+// and reports whether there was one.
+func (d *Dropdown) SelectItem(item string) bool {
+	for i, it := range d.Items {
+		if it == item {
+			d.SetSelected(i)
+			return true
+		}
+	}
+	return false
+}
+
+// Open opens the list of all the items, with the selected one highlighted.
+func (d *Dropdown) Open() {
+	d.showAll()
+	d.IsOpen = true
+	d.IsActive = true
+	d.wheel = 0
+	d.highlighted = d.position(d.Selected())
+	d.ensureVisible()
+}
+
+// Close closes the list.
+func (d *Dropdown) Close() {
+	d.IsOpen = false
+	d.pressedRow = -1
+}
+
+// choose selects the item at index, closes the list and calls OnChange if the
+// selection changed.
+func (d *Dropdown) choose(index int) {
+	changed := index != d.selected
+	if d.Field != nil {
+		changed = changed || d.Field.Text != d.Items[index]
+	}
+	d.SetSelected(index)
+	d.Close()
+	if changed && d.OnChange != nil {
+		d.OnChange(index, d.Items[index])
+	}
+}
+
+// showAll lists every item.
+func (d *Dropdown) showAll() {
+	d.visible = d.visible[:0]
+	for i := range d.Items {
+		d.visible = append(d.visible, i)
+	}
+}
+
+// position returns where an item is in the open list, or -1 if it is not there.
+func (d *Dropdown) position(index int) int {
+	for pos, i := range d.visible {
+		if i == index {
+			return pos
+		}
+	}
+	return -1
+}
+
+// maxVisible returns how many items the open list shows at most.
+func (d *Dropdown) maxVisible() int {
+	if d.MaxVisible <= 0 {
+		return DefaultMaxVisible
+	}
+	return d.MaxVisible
+}
+
+// rows returns how many items the open list shows.
+func (d *Dropdown) rows() int {
+	return min(len(d.visible), d.maxVisible())
+}
+
+// popupRect returns the bounds of the open list, just below the dropdown.
+func (d *Dropdown) popupRect() (x, y, w, h float32) {
+	return d.Bounds.X, d.Bounds.Y + d.Bounds.H, d.Bounds.W, d.ItemHeight * float32(d.rows())
+}
+
+// rowAt returns the position in the open list of the item at x, y, or -1.
+func (d *Dropdown) rowAt(x, y float32) int {
+	px, py, pw, ph := d.popupRect()
+	if x < px || x > px+pw || y < py || y >= py+ph || d.ItemHeight <= 0 {
+		return -1
+	}
+	return d.scroll + int((y-py)/d.ItemHeight)
+}
+
+// arrowWidth returns the width of the arrow at the right of the dropdown.
+func (d *Dropdown) arrowWidth() float32 {
+	return min(d.Bounds.H, d.Bounds.W/2)
+}
+
+// scrollBy scrolls the open list by n items, keeping it within the items.
+func (d *Dropdown) scrollBy(n int) {
+	d.scroll = max(0, min(d.scroll+n, len(d.visible)-d.rows()))
+}
+
+// ensureVisible scrolls the open list so the highlighted item is shown.
+func (d *Dropdown) ensureVisible() {
+	if d.highlighted < 0 {
+		d.scroll = 0
+		return
+	}
+	if d.highlighted < d.scroll {
+		d.scroll = d.highlighted
+	} else if d.highlighted >= d.scroll+d.rows() {
+		d.scroll = d.highlighted - d.rows() + 1
+	}
+	d.scrollBy(0)
+}
+
+// Update should be called every frame.
+func (d *Dropdown) Update() {
+	// The list keeps covering other widgets for the rest of the frame it
+	// closes in, so the click that closed it cannot reach them.
+	if !d.IsOpen {
+		overlay.Uncover(d)
+	}
+	if d.Field != nil {
+		d.updateField()
+	}
+
+	d.Button.Update()
+	if !d.Enabled || d.Uneditable {
+		d.Close()
+		d.IsActive = false
+		return
+	}
+
+	in := input.Or(d.Input)
+	mx, my := in.CursorPosition()
+	x, y := float32(mx), float32(my)
+	d.repeater.Update(in, input.KeyRepeatOr(d.KeyRepeat), clock.Or(d.Clock).Delta())
+	d.typedTimer -= clock.Or(d.Clock).Delta()
+
+	// In an editable dropdown only the arrow opens the list; the rest of it
+	// belongs to the text field.
+	onArrow := d.Field == nil || x >= d.Bounds.X+d.Bounds.W-d.arrowWidth()
+	if d.IsClicked() && onArrow {
+		if d.IsOpen {
+			d.Close()
+		} else {
+			d.Open()
+		}
+	} else if d.IsOpen {
+		d.updatePopupMouse(in, x, y)
+	}
+
+	if in.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if d.IsHovered {
+			d.IsActive = true
+		} else if !d.IsOpen {
+			d.IsActive = false
+		}
+	}
+	if d.Field != nil {
+		d.IsActive = d.Field.IsActive || d.IsOpen
+	}
+	if d.IsActive {
+		d.handleKeys(in)
+	}
+
+	if d.IsOpen {
+		px, py, pw, ph := d.popupRect()
+		overlay.Cover(d, px, py, pw, ph)
+	}
+}
+
+// updatePopupMouse highlights the item under the mouse, chooses clicked
+// items, scrolls with the wheel and closes the list on clicks elsewhere.
+func (d *Dropdown) updatePopupMouse(in input.Input, x, y float32) {
+	row := d.rowAt(x, y)
+	if row >= 0 && row < len(d.visible) {
+		d.highlighted = row
+	}
+	if _, dy := in.Wheel(); dy != 0 && row >= 0 {
+		// Trackpads and smooth wheels move a fraction of a notch at a time.
+		d.wheel += dy
+		n := int(d.wheel)
+		d.wheel -= float64(n)
+		d.scrollBy(-n)
+	}
+
+	if in.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		d.pressedRow = row
+		if row < 0 && !d.IsHovered {
+			d.Close()
+			d.IsActive = false
+			return
+		}
+	}
+	if in.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		if row >= 0 && row == d.pressedRow && row < len(d.visible) {
+			d.choose(d.visible[row])
+		}
+		d.pressedRow = -1
+	}
+}
+
+// handleKeys moves through the items, chooses them and jumps to typed text.
+func (d *Dropdown) handleKeys(in input.Input) {
+	page := d.maxVisible()
+	moves := []struct {
+		key ebiten.Key
+		by  int
+	}{
+		{ebiten.KeyUp, -1},
+		{ebiten.KeyDown, 1},
+		{ebiten.KeyPageUp, -page},
+		{ebiten.KeyPageDown, page},
+	}
+	for _, m := range moves {
+		for range d.repeater.Presses(in, m.key) {
+			d.move(m.by)
+		}
+	}
+	// An editable dropdown's field keeps Home and End for its caret.
+	if d.Field == nil {
+		if in.IsKeyJustPressed(ebiten.KeyHome) {
+			d.move(-len(d.Items))
+		}
+		if in.IsKeyJustPressed(ebiten.KeyEnd) {
+			d.move(len(d.Items))
+		}
+	}
+
+	switch {
+	case in.IsKeyJustPressed(ebiten.KeyEnter) || in.IsKeyJustPressed(ebiten.KeyNumpadEnter):
+		d.enter()
+	case in.IsKeyJustPressed(ebiten.KeySpace) && d.Field == nil && !d.IsOpen:
+		d.Open()
+	case in.IsKeyJustPressed(ebiten.KeyEscape):
+		if !d.IsOpen {
+			d.IsActive = false
+		}
+		d.Close()
+	}
+
+	if d.Field == nil {
+		d.typeAhead(in.AppendInputChars(nil))
+	}
+}
+
+// move moves the highlight in the open list, or the selection while closed,
+// by n items, stopping at the ends. An editable dropdown opens its list.
+func (d *Dropdown) move(n int) {
+	if !d.IsOpen && d.Field != nil {
+		d.Open()
+		return
+	}
+	if !d.IsOpen {
+		if len(d.Items) > 0 {
+			d.choose(max(0, min(d.Selected()+n, len(d.Items)-1)))
+		}
+		return
+	}
+	if len(d.visible) == 0 {
+		return
+	}
+	if d.highlighted < 0 && n > 0 {
+		d.highlighted = -1 + n
+	} else {
+		d.highlighted += n
+	}
+	d.highlighted = max(0, min(d.highlighted, len(d.visible)-1))
+	d.ensureVisible()
+}
+
+// enter chooses the highlighted item, opens a closed list, or in an editable
+// dropdown accepts the typed text.
+func (d *Dropdown) enter() {
+	switch {
+	case d.IsOpen && d.highlighted >= 0 && d.highlighted < len(d.visible):
+		d.choose(d.visible[d.highlighted])
+	case d.Field != nil:
+		d.acceptText()
+	case !d.IsOpen:
+		d.Open()
+	default:
+		d.Close()
+	}
+}
+
+// typeAhead highlights, or while closed selects, the next item starting with
+// the characters typed in quick succession. Typing the same letter again
+// cycles through the items starting with it.
+func (d *Dropdown) typeAhead(chars []rune) {
+	if len(chars) == 0 {
+		return
+	}
+	if d.typedTimer <= 0 {
+		d.typed = ""
+	}
+	// Space opens the list rather than starting a search.
+	d.typed += strings.ToLower(string(chars))
+	if strings.TrimSpace(d.typed) == "" {
+		d.typed = ""
+		return
+	}
+	d.typedTimer = typeAheadTimeout
+
+	current := d.Selected()
+	if d.IsOpen && d.highlighted >= 0 {
+		current = d.visible[d.highlighted]
+	}
+	// A fresh search starts after the current item; a longer prefix may
+	// still match it.
+	start := current + 1
+	if len([]rune(d.typed)) > 1 {
+		start = max(current, 0)
+	}
+	for k := range len(d.Items) {
+		i := (start + k) % len(d.Items)
+		if strings.HasPrefix(strings.ToLower(d.Items[i]), d.typed) {
+			if d.IsOpen {
+				d.showAll()
+				d.highlighted = i
+				d.ensureVisible()
+			} else {
+				d.choose(i)
+			}
+			return
+		}
+	}
+}
+
+// Draw draws the dropdown and defers drawing the open list until overlay.Draw.
+func (d *Dropdown) Draw(screen *ebiten.Image) {
+	if d.Invisible {
+		return
+	}
+	d.Button.Draw(screen)
+
+	arrow := d.arrowWidth()
+	size := arrow * 0.3
+	ax := d.Bounds.X + d.Bounds.W - arrow/2 - size/2
+	ay := d.Bounds.Y + d.Bounds.H/2 - size/4
+	dir := draw.Down
+	if d.IsOpen {
+		dir = draw.Up
+	}
+	draw.Chevron(screen, ax, ay, size, size/2, dir, 2, d.TextColor)
+
+	if d.Field != nil {
+		d.Field.Draw(screen)
+	} else if d.FontFace != nil {
+		label, col := d.Text(), d.TextColor
+		if d.Selected() < 0 {
+			label, col = d.Placeholder, color.RGBA{R: 128, G: 128, B: 128, A: 255} // Gray like a placeholder
+		}
+		clip := image.Rect(int(d.Bounds.X), int(d.Bounds.Y), int(d.Bounds.X+d.Bounds.W-arrow), int(d.Bounds.Y+d.Bounds.H))
+		textY := d.Bounds.Y + (d.Bounds.H-float32(d.FontSize))/2
+		text.Draw(screen.SubImage(clip).(*ebiten.Image), label, d.FontFace, int(d.Bounds.X+d.Padding), int(textY)+int(d.FontSize), col)
+	}
+
+	if d.IsOpen && len(d.visible) > 0 {
+		overlay.Defer(d, d.drawPopup)
+	}
+}
+
+// drawPopup draws the open list with its scrollbar.
+func (d *Dropdown) drawPopup(screen *ebiten.Image) {
+	x, y, w, h := d.popupRect()
+	draw.FillRect(screen, x, y, w, h, d.PopupColor)
+
+	rows := d.rows()
+	scrollbar := float32(0)
+	if len(d.visible) > rows {
+		scrollbar = 6
+	}
+	content := screen.SubImage(image.Rect(int(x), int(y), int(x+w-scrollbar), int(y+h))).(*ebiten.Image)
+	for row := range rows {
+		pos := d.scroll + row
+		top := y + float32(row)*d.ItemHeight
+		if pos == d.highlighted {
+			draw.FillRect(content, x, top, w-scrollbar, d.ItemHeight, d.HighlightColor)
+		}
+		if d.FontFace != nil {
+			textY := top + (d.ItemHeight-float32(d.FontSize))/2
+			text.Draw(content, d.Items[d.visible[pos]], d.FontFace, int(x+d.Padding), int(textY)+int(d.FontSize), d.TextColor)
+		}
+	}
+
+	if scrollbar > 0 {
+		thumbH := h * float32(rows) / float32(len(d.visible))
+		thumbY := y + h*float32(d.scroll)/float32(len(d.visible))
+		draw.FillRect(screen, x+w-scrollbar, y, scrollbar, h, paint.Fade(d.BorderColor, 0.15))
+		draw.FillRect(screen, x+w-scrollbar, thumbY, scrollbar, thumbH, paint.Fade(d.BorderColor, 0.5))
+	}
+	draw.StrokeRect(screen, x, y, w, h, 1, d.BorderColor)
+}
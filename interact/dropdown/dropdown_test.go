@@ -0,0 +1,229 @@
+package dropdown_test
+
+import (
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/button"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/dropdown"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/testutil"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var resolutions = []string{"640x480", "800x600", "1024x768", "1280x720", "1366x768", "1600x900", "1920x1080", "2560x1440", "3840x2160", "5120x2880"}
+
+// newResolutions returns a dropdown at (10, 10) 200x30 showing up to four
+// items, so row r of the open list is centred at y=55+30r.
+func newResolutions() *dropdown.Dropdown {
+	d := dropdown.New(10, 10, 200, 30, resolutions...)
+	d.SetMaxVisible(4)
+	return d
+}
+
+func rowY(r int) int {
+	return 55 + 30*r
+}
+
+func TestClickOpensAndChooses(t *testing.T) {
+	d := newResolutions()
+	var got []int
+	d.OnChange = func(index int, item string) {
+		if item != resolutions[index] {
+			t.Errorf("OnChange(%d, %q)", index, item)
+		}
+		got = append(got, index)
+	}
+	h := testutil.NewHarness(d)
+	defer h.Close()
+
+	h.Click(100, 25)
+	if !d.IsOpen {
+		t.Fatal("click did not open the list")
+	}
+	h.Click(100, rowY(2))
+	if d.IsOpen || d.Selected() != 2 || d.Text() != "1024x768" {
+		t.Fatalf("open=%v selected=%d, want the third item chosen and the list closed", d.IsOpen, d.Selected())
+	}
+	if len(got) != 1 || got[0] != 2 {
+		t.Fatalf("OnChange called with %v, want [2]", got)
+	}
+
+	// Clicking the dropdown again toggles the list.
+	h.Click(100, 25)
+	h.Click(100, 25)
+	if d.IsOpen {
+		t.Fatal("second click did not close the list")
+	}
+}
+
+func TestOutsideClickAndEscapeClose(t *testing.T) {
+	d := newResolutions()
+	h := testutil.NewHarness(d)
+	defer h.Close()
+
+	h.Click(100, 25)
+	h.Click(400, 400)
+	if d.IsOpen {
+		t.Fatal("click outside did not close the list")
+	}
+	h.Click(100, 25)
+	h.PressKeys(ebiten.KeyEscape)
+	if d.IsOpen {
+		t.Fatal("Escape did not close the list")
+	}
+	if d.Selected() != -1 {
+		t.Fatal("closing the list selected an item")
+	}
+}
+
+func TestListCoversWidgetsBelow(t *testing.T) {
+	d := newResolutions()
+	below := button.NewButton(10, 60, 200, 30, "Apply")
+	h := testutil.NewHarness(d, below)
+	defer h.Close()
+
+	h.Click(100, 25)
+	h.Click(100, 65) // Over both the first item and the button
+	if below.IsClicked() {
+		t.Fatal("click on the list went through to the button below")
+	}
+	if d.Selected() != 0 {
+		t.Fatalf("selected %d, want the first item", d.Selected())
+	}
+	h.Click(100, 65)
+	if !below.IsClicked() {
+		t.Fatal("button below still blocked after the list closed")
+	}
+}
+
+func TestKeyboardNavigation(t *testing.T) {
+	d := newResolutions()
+	h := testutil.NewHarness(d)
+	defer h.Close()
+
+	h.Click(100, 25) // Open and activate
+	for range 5 {
+		h.PressKeys(ebiten.KeyDown)
+	}
+	h.PressKeys(ebiten.KeyEnter)
+	if d.IsOpen || d.Selected() != 4 {
+		t.Fatalf("open=%v selected=%d after five Downs and Enter, want 4", d.IsOpen, d.Selected())
+	}
+
+	// While closed, the arrow keys change the selection directly.
+	h.PressKeys(ebiten.KeyUp)
+	if d.Selected() != 3 || d.IsOpen {
+		t.Fatalf("selected %d after Up while closed, want 3", d.Selected())
+	}
+	h.PressKeys(ebiten.KeySpace)
+	h.PressKeys(ebiten.KeyEnd)
+	h.PressKeys(ebiten.KeyEnter)
+	if d.Selected() != len(resolutions)-1 {
+		t.Fatalf("selected %d after End, want the last item", d.Selected())
+	}
+}
+
+func TestTypeToJump(t *testing.T) {
+	d := dropdown.New(10, 10, 200, 30, "English", "Español", "Deutsch", "Eesti", "Français")
+	h := testutil.NewHarness(d)
+	defer h.Close()
+
+	h.Click(100, 25)
+	h.PressKeys(ebiten.KeyEscape) // Closed but still active
+	h.TypeString("f")
+	if d.Selected() != 4 {
+		t.Fatalf("typing f selected %d, want Français", d.Selected())
+	}
+	h.Advance(90) // Let the search time out
+	h.TypeString("e")
+	h.TypeString("e")
+	if d.Selected() != 3 {
+		t.Fatalf("typing ee selected %d, want Eesti", d.Selected())
+	}
+	h.Advance(90)
+	h.TypeString("e")
+	if d.Selected() != 0 {
+		t.Fatalf("typing e again selected %d, want to cycle round to English", d.Selected())
+	}
+}
+
+func TestWheelScrollsTheList(t *testing.T) {
+	d := newResolutions()
+	h := testutil.NewHarness(d)
+	defer h.Close()
+
+	h.Click(100, 25)
+	h.Input.MoveCursor(100, rowY(0))
+	h.Input.Scroll(0, -3)
+	h.Frame()
+	h.Click(100, rowY(0))
+	if d.Selected() != 3 {
+		t.Fatalf("selected %d after scrolling down 3 and clicking the top row, want 3", d.Selected())
+	}
+
+	// Scrolling stops at the end of the list.
+	h.Click(100, 25)
+	h.Input.MoveCursor(100, rowY(0))
+	h.Input.Scroll(0, -100)
+	h.Frame()
+	h.Click(100, rowY(3))
+	if d.Selected() != len(resolutions)-1 {
+		t.Fatalf("selected %d from the bottom row, want the last item", d.Selected())
+	}
+}
+
+func TestSmoothWheelScrollsTheList(t *testing.T) {
+	d := newResolutions()
+	h := testutil.NewHarness(d)
+	defer h.Close()
+
+	h.Click(100, 25)
+	h.Input.MoveCursor(100, rowY(0))
+	// A trackpad moves a fraction of a notch each frame.
+	for range 5 {
+		h.Input.Scroll(0, -0.25)
+		h.Frame()
+	}
+	h.Click(100, rowY(0))
+	if d.Selected() != 1 {
+		t.Fatalf("selected %d after scrolling down 1.25 items and clicking the top row, want 1", d.Selected())
+	}
+}
+
+func TestEditableFilters(t *testing.T) {
+	d := dropdown.NewEditable(10, 10, 200, 30, "Apple", "Apricot", "Banana", "Pineapple")
+	var index = -2
+	var item string
+	d.OnChange = func(i int, s string) { index, item = i, s }
+	h := testutil.NewHarness(d)
+	defer h.Close()
+
+	h.Click(50, 25) // Into the field
+	if !d.Field.IsActive || d.IsOpen {
+		t.Fatal("clicking the text should focus the field without opening the list")
+	}
+	h.TypeString("app")
+	if !d.IsOpen {
+		t.Fatal("typing did not open the list")
+	}
+	// Apple and Pineapple contain "app"; the first is highlighted.
+	h.PressKeys(ebiten.KeyDown)
+	h.PressKeys(ebiten.KeyEnter)
+	if d.Selected() != 3 || d.Text() != "Pineapple" || index != 3 {
+		t.Fatalf("selected %d text %q, want Pineapple", d.Selected(), d.Text())
+	}
+
+	// Text matching no item is reported with index -1.
+	d.Field.SetValue("")
+	h.TypeString("Cherry")
+	h.PressKeys(ebiten.KeyEnter)
+	if d.Selected() != -1 || index != -1 || item != "Cherry" {
+		t.Fatalf("selected %d, OnChange(%d, %q), want custom text Cherry", d.Selected(), index, item)
+	}
+
+	// The arrow opens the whole list.
+	h.Click(200, 25)
+	h.Click(100, rowY(2))
+	if d.Text() != "Banana" {
+		t.Fatalf("text %q after choosing from the full list, want Banana", d.Text())
+	}
+}
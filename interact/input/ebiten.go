@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+package input
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// ebitenInput reads input straight from ebiten and inpututil.
+type ebitenInput struct{}
+
+// Ebiten returns the input source backed by ebiten's global input state.
+func Ebiten() Input {
+	return ebitenInput{}
+}
+
+func (ebitenInput) CursorPosition() (int, int) {
+	return ebiten.CursorPosition()
+}
+
+func (ebitenInput) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	return ebiten.IsMouseButtonPressed(button)
+}
+
+func (ebitenInput) IsMouseButtonJustPressed(button ebiten.MouseButton) bool {
+	return inpututil.IsMouseButtonJustPressed(button)
+}
+
+func (ebitenInput) IsMouseButtonJustReleased(button ebiten.MouseButton) bool {
+	return inpututil.IsMouseButtonJustReleased(button)
+}
+
+func (ebitenInput) IsKeyPressed(key ebiten.Key) bool {
+	return ebiten.IsKeyPressed(key)
+}
+
+func (ebitenInput) IsKeyJustPressed(key ebiten.Key) bool {
+	return inpututil.IsKeyJustPressed(key)
+}
+
+func (ebitenInput) AppendInputChars(runes []rune) []rune {
+	return ebiten.AppendInputChars(runes)
+}
+
+func (ebitenInput) Wheel() (float64, float64) {
+	return ebiten.Wheel()
+}
+
+func (ebitenInput) AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID {
+	return ebiten.AppendTouchIDs(touches)
+}
+
+func (ebitenInput) TouchPosition(id ebiten.TouchID) (int, int) {
+	return ebiten.TouchPosition(id)
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: MIT
+package input
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Input is the source every widget reads mouse, keyboard and touch state from.
+// The default implementation forwards to ebiten, but any implementation can be
+// installed with SetDefault (or per widget with SetInput) to remap inputs, replay
+// recorded sessions or drive widgets from tests without a window.
+type Input interface {
+	// CursorPosition returns the mouse cursor position in screen coordinates.
+	CursorPosition() (x, y int)
+	// IsMouseButtonPressed reports whether the mouse button is currently held.
+	IsMouseButtonPressed(button ebiten.MouseButton) bool
+	// IsMouseButtonJustPressed reports whether the mouse button went down this frame.
+	IsMouseButtonJustPressed(button ebiten.MouseButton) bool
+	// IsMouseButtonJustReleased reports whether the mouse button went up this frame.
+	IsMouseButtonJustReleased(button ebiten.MouseButton) bool
+	// IsKeyPressed reports whether the key is currently held.
+	IsKeyPressed(key ebiten.Key) bool
+	// IsKeyJustPressed reports whether the key went down this frame.
+	IsKeyJustPressed(key ebiten.Key) bool
+	// AppendInputChars appends the runes typed this frame to runes and returns the result.
+	AppendInputChars(runes []rune) []rune
+	// Wheel returns the mouse wheel movement for this frame.
+	Wheel() (xoff, yoff float64)
+	// AppendTouchIDs appends the IDs of the active touches to touches and returns the result.
+	AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID
+	// TouchPosition returns the position of the touch with the given ID.
+	TouchPosition(id ebiten.TouchID) (x, y int)
+}
+
+var defaultInput Input = Ebiten()
+
+// Default returns the input source used by widgets that have no input of their own.
+func Default() Input {
+	return defaultInput
+}
+
+// SetDefault replaces the input source used by widgets that have no input of their own.
+// Passing nil restores the ebiten-backed input.
+func SetDefault(in Input) {
+	if in == nil {
+		in = Ebiten()
+	}
+	defaultInput = in
+}
+
+// Or returns in if it is non-nil and the default input otherwise.
+// Widgets use it to resolve their optional Input field.
+func Or(in Input) Input {
+	if in != nil {
+		return in
+	}
+	return defaultInput
+}
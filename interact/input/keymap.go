@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+package input
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Keymap maps the logical modifiers used by editing shortcuts to physical keys,
+// so the same widget code responds to Ctrl+C on PCs and Cmd+C on macOS.
+type Keymap struct {
+	Shortcut ebiten.Key // Held for copy, cut, paste, select all...
+	Word     ebiten.Key // Held to move or delete by word with the arrows, Backspace and Delete
+}
+
+// PCKeymap uses Control for shortcuts and word movement.
+func PCKeymap() Keymap {
+	return Keymap{
+		Shortcut: ebiten.KeyControl,
+		Word:     ebiten.KeyControl,
+	}
+}
+
+// MacKeymap uses Command (Meta) for shortcuts and Option (Alt) for word movement.
+func MacKeymap() Keymap {
+	return Keymap{
+		Shortcut: ebiten.KeyMeta,
+		Word:     ebiten.KeyAlt,
+	}
+}
+
+var defaultKeymap = platformKeymap()
+
+func platformKeymap() Keymap {
+	if isApple() {
+		return MacKeymap()
+	}
+	return PCKeymap()
+}
+
+// DefaultKeymap returns the keymap used by widgets that have no keymap of their own.
+// It is MacKeymap on macOS and iOS, including WASM builds running in a browser
+// there, and PCKeymap everywhere else.
+func DefaultKeymap() Keymap {
+	return defaultKeymap
+}
+
+// SetDefaultKeymap replaces the keymap used by widgets that have no keymap of their own.
+func SetDefaultKeymap(k Keymap) {
+	defaultKeymap = k
+}
+
+// KeymapOr returns *k if k is non-nil and the default keymap otherwise.
+func KeymapOr(k *Keymap) Keymap {
+	if k != nil {
+		return *k
+	}
+	return defaultKeymap
+}
+
+// IsShortcut reports whether key was just pressed while the shortcut modifier is held.
+func (k Keymap) IsShortcut(in Input, key ebiten.Key) bool {
+	return in.IsKeyJustPressed(key) && in.IsKeyPressed(k.Shortcut)
+}
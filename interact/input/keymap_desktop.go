@@ -0,0 +1,11 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package input
+
+import "runtime"
+
+// isApple reports whether the program runs on macOS or iOS.
+func isApple() bool {
+	return runtime.GOOS == "darwin" || runtime.GOOS == "ios"
+}
@@ -0,0 +1,30 @@
+//go:build js && wasm
+// +build js,wasm
+
+package input
+
+import (
+	"strings"
+	"syscall/js"
+)
+
+// isApple reports whether the browser runs on macOS or iOS. runtime.GOOS is
+// "js" in every browser, so the platform comes from the navigator instead.
+func isApple() bool {
+	nav := js.Global().Get("navigator")
+	if !nav.Truthy() {
+		return false
+	}
+	platform := ""
+	if data := nav.Get("userAgentData"); data.Truthy() && data.Get("platform").Truthy() {
+		platform = data.Get("platform").String() // "macOS" in Chromium
+	} else if p := nav.Get("platform"); p.Truthy() {
+		platform = p.String() // "MacIntel", "iPhone", "iPad"...
+	}
+	for _, prefix := range []string{"Mac", "iPhone", "iPad", "iPod"} {
+		if strings.HasPrefix(platform, prefix) {
+			return true
+		}
+	}
+	return platform == "macOS"
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+package input
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// KeyRepeat is the timing of repeated key presses while a key is held.
+type KeyRepeat struct {
+	Delay    float32 // Seconds a key is held before it starts repeating
+	Interval float32 // Seconds between repeats; zero or less repeats only once
+}
+
+// DefaultKeyRepeat returns the key repeat used by widgets that have none of
+// their own. It starts with the common desktop default of a 0.5s delay and
+// 30 repeats a second.
+func DefaultKeyRepeat() KeyRepeat {
+	return defaultKeyRepeat
+}
+
+var defaultKeyRepeat = KeyRepeat{Delay: 0.5, Interval: 1.0 / 30.0}
+
+// SetDefaultKeyRepeat replaces the key repeat used by widgets that have none of their own.
+func SetDefaultKeyRepeat(r KeyRepeat) {
+	defaultKeyRepeat = r
+}
+
+// KeyRepeatOr returns *r if r is non-nil and the default key repeat otherwise.
+func KeyRepeatOr(r *KeyRepeat) KeyRepeat {
+	if r != nil {
+		return *r
+	}
+	return defaultKeyRepeat
+}
+
+// count returns how many repeats are due after a key was held for the given time.
+func (r KeyRepeat) count(held float32) int {
+	if held < r.Delay {
+		return 0
+	}
+	if r.Interval <= 0 {
+		return 1
+	}
+	return int((held-r.Delay)/r.Interval) + 1
+}
+
+// Repeater turns held keys into repeated presses for one widget. Like an
+// operating system, only the most recently pressed key repeats. Call Update
+// once per frame, then Presses for each key the widget acts on.
+type Repeater struct {
+	key     ebiten.Key
+	active  bool
+	held    float32
+	pending int
+}
+
+// Update advances the held key by dt seconds and works out how many repeats
+// fall within this frame. A key released or never pressed stops repeating.
+func (r *Repeater) Update(in Input, timing KeyRepeat, dt float32) {
+	r.pending = 0
+	if !r.active {
+		return
+	}
+	if !in.IsKeyPressed(r.key) {
+		r.active = false
+		return
+	}
+	before := timing.count(r.held)
+	r.held += dt
+	r.pending = timing.count(r.held) - before
+}
+
+// Presses returns how many times the action bound to key should run this
+// frame: once when the key is first pressed, then once per repeat. Slow frames
+// can include several repeats.
+func (r *Repeater) Presses(in Input, key ebiten.Key) int {
+	if in.IsKeyJustPressed(key) {
+		r.key = key
+		r.active = true
+		r.held = 0
+		r.pending = 0
+		return 1
+	}
+	if r.active && r.key == key {
+		return r.pending
+	}
+	return 0
+}
+
+// Shortcut is like Presses but only counts presses while the keymap's
+// shortcut modifier is held, for repeating shortcuts such as undo.
+func (r *Repeater) Shortcut(in Input, k Keymap, key ebiten.Key) int {
+	n := r.Presses(in, key)
+	if !in.IsKeyPressed(k.Shortcut) {
+		return 0
+	}
+	return n
+}
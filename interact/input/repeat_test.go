@@ -0,0 +1,91 @@
+package input_test
+
+import (
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/testutil"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// presses runs frames of dt seconds and returns how often key fired in each.
+func presses(in *testutil.FakeInput, r *input.Repeater, timing input.KeyRepeat, dt float32, frames int, key ebiten.Key) []int {
+	var got []int
+	for i := 0; i < frames; i++ {
+		r.Update(in, timing, dt)
+		got = append(got, r.Presses(in, key))
+		in.EndFrame()
+	}
+	return got
+}
+
+func TestRepeaterDelayAndInterval(t *testing.T) {
+	in := testutil.NewFakeInput()
+	var r input.Repeater
+	timing := input.KeyRepeat{Delay: 0.5, Interval: 0.25}
+
+	in.PressKey(ebiten.KeyArrowLeft)
+	got := presses(in, &r, timing, 0.125, 9, ebiten.KeyArrowLeft)
+	want := []int{1, 0, 0, 0, 1, 0, 1, 0, 1}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("presses per frame %v, want %v", got, want)
+		}
+	}
+
+	in.ReleaseKey(ebiten.KeyArrowLeft)
+	if got := presses(in, &r, timing, 1, 2, ebiten.KeyArrowLeft); got[0] != 0 || got[1] != 0 {
+		t.Fatalf("released key still fires: %v", got)
+	}
+}
+
+func TestRepeaterCatchesUpOnSlowFrames(t *testing.T) {
+	in := testutil.NewFakeInput()
+	var r input.Repeater
+	timing := input.KeyRepeat{Delay: 0.5, Interval: 0.25}
+
+	in.PressKey(ebiten.KeyBackspace)
+	got := presses(in, &r, timing, 1, 2, ebiten.KeyBackspace)
+	// One second held covers the delay plus two intervals.
+	if got[0] != 1 || got[1] != 3 {
+		t.Fatalf("presses %v, want [1 3]", got)
+	}
+}
+
+func TestRepeaterOnlyRepeatsLatestKey(t *testing.T) {
+	in := testutil.NewFakeInput()
+	var r input.Repeater
+	timing := input.KeyRepeat{Delay: 0.5, Interval: 0.25}
+
+	in.PressKey(ebiten.KeyA)
+	presses(in, &r, timing, 0.25, 1, ebiten.KeyA)
+	in.PressKey(ebiten.KeyB)
+	r.Update(in, timing, 0.25)
+	if n := r.Presses(in, ebiten.KeyB); n != 1 {
+		t.Fatalf("B pressed %d times, want 1", n)
+	}
+	in.EndFrame()
+
+	for i := 0; i < 4; i++ {
+		r.Update(in, timing, 0.25)
+		if n := r.Presses(in, ebiten.KeyA); n != 0 {
+			t.Fatalf("A still repeats after B was pressed")
+		}
+		in.EndFrame()
+	}
+}
+
+func TestKeyRepeatOr(t *testing.T) {
+	prev := input.DefaultKeyRepeat()
+	t.Cleanup(func() { input.SetDefaultKeyRepeat(prev) })
+
+	custom := input.KeyRepeat{Delay: 0.3, Interval: 0.05}
+	input.SetDefaultKeyRepeat(custom)
+	if input.KeyRepeatOr(nil) != custom {
+		t.Fatal("KeyRepeatOr(nil) did not return the default")
+	}
+	own := input.KeyRepeat{Delay: 1}
+	if input.KeyRepeatOr(&own) != own {
+		t.Fatal("KeyRepeatOr ignored the widget's own timing")
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+
+// Package paint draws the parts of a colorscheme.Style that widgets share:
+// drop shadows, gradient backgrounds and inner highlights. It also holds the
+// color math they share, which works on premultiplied color.RGBA values.
+package paint
+
+import (
+	"image/color"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/colorscheme"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/draw"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Background returns the background for state: its gradient if the style has
+// one and flat otherwise.
+func Background(style colorscheme.Style, state colorscheme.State, flat color.RGBA) colorscheme.Gradient {
+	if g := style.Gradients.For(state); g != nil {
+		return *g
+	}
+	return colorscheme.Flat(flat)
+}
+
+// LerpGradient blends two backgrounds, for animating between states. The
+// direction switches halfway.
+func LerpGradient(a, b colorscheme.Gradient, t float32) colorscheme.Gradient {
+	horizontal := a.Horizontal
+	if t >= 0.5 {
+		horizontal = b.Horizontal
+	}
+	return colorscheme.Gradient{
+		From:       LerpColor(a.From, b.From, t),
+		To:         LerpColor(a.To, b.To, t),
+		Horizontal: horizontal,
+	}
+}
+
+// LerpColor blends from c1 at t=0 to c2 at t=1.
+func LerpColor(c1, c2 color.RGBA, t float32) color.RGBA {
+	lerp := func(a, b uint8) uint8 {
+		return uint8(float32(a) + (float32(b)-float32(a))*t + 0.5)
+	}
+	return color.RGBA{R: lerp(c1.R, c2.R), G: lerp(c1.G, c2.G), B: lerp(c1.B, c2.B), A: lerp(c1.A, c2.A)}
+}
+
+// Fade scales a premultiplied color's opacity by factor, scaling the color
+// channels with it so they never exceed the alpha.
+func Fade(c color.RGBA, factor float32) color.RGBA {
+	scale := func(v uint8) uint8 {
+		return uint8(float32(v)*factor + 0.5)
+	}
+	return color.RGBA{R: scale(c.R), G: scale(c.G), B: scale(c.B), A: scale(c.A)}
+}
+
+// Gradient converts a style gradient for drawing.
+func Gradient(g colorscheme.Gradient) draw.Gradient {
+	dir := draw.Down
+	if g.Horizontal {
+		dir = draw.Right
+	}
+	return draw.Gradient{From: g.From, To: g.To, Dir: dir}
+}
+
+// Shadow draws the style's drop shadow, if it has one, for a rounded rectangle.
+func Shadow(dst *ebiten.Image, x, y, w, h float32, radii draw.Radii, style colorscheme.Style) {
+	if s := style.Shadow; s != nil {
+		draw.Shadow(dst, x+s.OffsetX, y+s.OffsetY, w, h, radii, s.Blur, s.Color)
+	}
+}
+
+// Highlight draws the style's inner highlight, if it has one, just inside a
+// border of the given width, fading out from the top to the bottom.
+func Highlight(dst *ebiten.Image, x, y, w, h float32, radii draw.Radii, border float32, style colorscheme.Style) {
+	hl := style.Highlight
+	if hl == nil || hl.Width <= 0 {
+		return
+	}
+	radii = draw.Radii{
+		TopLeft:     radii.TopLeft - border,
+		TopRight:    radii.TopRight - border,
+		BottomRight: radii.BottomRight - border,
+		BottomLeft:  radii.BottomLeft - border,
+	}
+	g := draw.Gradient{From: hl.Color, To: color.RGBA{}, Dir: draw.Down}
+	draw.StrokeRoundedRectGradient(dst, x+border, y+border, w-2*border, h-2*border, radii, hl.Width, g)
+}
@@ -0,0 +1,51 @@
+package paint_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/colorscheme"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/paint"
+)
+
+func TestLerpColorBlendsDownwards(t *testing.T) {
+	got := paint.LerpColor(color.RGBA{R: 211, G: 0, B: 100, A: 255}, color.RGBA{R: 169, G: 200, B: 100, A: 255}, 0.5)
+	want := color.RGBA{R: 190, G: 100, B: 100, A: 255}
+	if got != want {
+		t.Fatalf("LerpColor = %v, want %v", got, want)
+	}
+}
+
+func TestFadeStaysPremultiplied(t *testing.T) {
+	got := paint.Fade(color.RGBA{R: 200, G: 100, B: 0, A: 200}, 0.5)
+	want := color.RGBA{R: 100, G: 50, B: 0, A: 100}
+	if got != want {
+		t.Fatalf("Fade = %v, want %v", got, want)
+	}
+}
+
+func TestBackgroundFallsBackToFlat(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	style := colorscheme.Style{Gradients: colorscheme.Gradients{
+		Hover: &colorscheme.Gradient{From: red, To: color.RGBA{A: 255}, Horizontal: true},
+	}}
+	if got := paint.Background(style, colorscheme.Normal, red); got != colorscheme.Flat(red) {
+		t.Fatalf("normal background = %v, want flat %v", got, red)
+	}
+	if got := paint.Background(style, colorscheme.Hover, red); got != *style.Gradients.Hover {
+		t.Fatalf("hover background = %v, want the hover gradient", got)
+	}
+}
+
+func TestLerpGradient(t *testing.T) {
+	a := colorscheme.Gradient{From: color.RGBA{A: 255}, To: color.RGBA{A: 255}}
+	b := colorscheme.Gradient{From: color.RGBA{R: 200, A: 255}, To: color.RGBA{G: 100, A: 255}, Horizontal: true}
+	got := paint.LerpGradient(a, b, 0.25)
+	want := colorscheme.Gradient{From: color.RGBA{R: 50, A: 255}, To: color.RGBA{G: 25, A: 255}}
+	if got != want {
+		t.Fatalf("LerpGradient at 0.25 = %v, want %v", got, want)
+	}
+	if !paint.LerpGradient(a, b, 0.75).Horizontal {
+		t.Fatal("LerpGradient past halfway keeps the first direction")
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: MIT
+
+// Package textutil holds the Unicode text helpers shared by the text editing
+// widgets. Positions are counted in grapheme clusters (user-perceived
+// characters), so "é", "🇯🇵" and "👩‍💻" are each a single position no matter
+// how many bytes or runes they take.
+package textutil
+
+import (
+	"github.com/rivo/uniseg"
+)
+
+// GraphemeCount returns the number of grapheme clusters in s.
+func GraphemeCount(s string) int {
+	return uniseg.GraphemeClusterCount(s)
+}
+
+// Offset returns the byte offset of the boundary before the i-th grapheme
+// cluster of s. i is clamped to [0, GraphemeCount(s)].
+func Offset(s string, i int) int {
+	offset := 0
+	state := -1
+	rest := s
+	for ; i > 0 && rest != ""; i-- {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		offset += len(cluster)
+	}
+	return offset
+}
+
+// Index returns the number of grapheme clusters before the byte offset in s.
+// An offset inside a cluster counts that cluster as before it.
+func Index(s string, offset int) int {
+	if offset > len(s) {
+		offset = len(s)
+	}
+	return GraphemeCount(s[:offset])
+}
+
+// Slice returns the grapheme clusters of s in [start, end).
+func Slice(s string, start, end int) string {
+	return s[Offset(s, start):Offset(s, end)]
+}
+
+// Truncate returns the first n grapheme clusters of s.
+func Truncate(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return s[:Offset(s, n)]
+}
+
+// Boundaries returns the byte offsets of every grapheme boundary in s,
+// including 0 and len(s).
+func Boundaries(s string) []int {
+	bounds := []int{0}
+	offset := 0
+	state := -1
+	rest := s
+	for rest != "" {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		offset += len(cluster)
+		bounds = append(bounds, offset)
+	}
+	return bounds
+}
@@ -0,0 +1,67 @@
+package textutil
+
+import (
+	"testing"
+)
+
+var graphemeTests = []struct {
+	name  string
+	s     string
+	count int
+}{
+	{"ascii", "hello", 5},
+	{"polish", "zażółć", 6},
+	{"precomposed", "é", 1},
+	{"combining", "e\u0301", 1},
+	{"japanese", "日本語", 3},
+	{"katakana", "ゲーム", 3},
+	{"emoji", "😀", 1},
+	{"flag", "🇯🇵🇵🇱", 2},
+	{"zwj", "👩‍💻!", 2},
+	{"skin tone", "👍🏽", 1},
+	{"empty", "", 0},
+}
+
+func TestGraphemeCount(t *testing.T) {
+	for _, tt := range graphemeTests {
+		if got := GraphemeCount(tt.s); got != tt.count {
+			t.Errorf("%s: GraphemeCount(%q) = %d, want %d", tt.name, tt.s, got, tt.count)
+		}
+	}
+}
+
+func TestOffsetAndIndexRoundTrip(t *testing.T) {
+	for _, tt := range graphemeTests {
+		bounds := Boundaries(tt.s)
+		if len(bounds) != tt.count+1 {
+			t.Fatalf("%s: %d boundaries, want %d", tt.name, len(bounds), tt.count+1)
+		}
+		for i, b := range bounds {
+			if got := Offset(tt.s, i); got != b {
+				t.Errorf("%s: Offset(%d) = %d, want %d", tt.name, i, got, b)
+			}
+			if got := Index(tt.s, b); got != i {
+				t.Errorf("%s: Index(%d) = %d, want %d", tt.name, b, got, i)
+			}
+		}
+		if got := Offset(tt.s, tt.count+5); got != len(tt.s) {
+			t.Errorf("%s: Offset past the end = %d, want %d", tt.name, got, len(tt.s))
+		}
+	}
+}
+
+func TestSliceAndTruncate(t *testing.T) {
+	s := "a👩‍💻ñ日"
+	if got := Slice(s, 1, 3); got != "👩‍💻ñ" {
+		t.Errorf("Slice = %q", got)
+	}
+	if got := Truncate(s, 2); got != "a👩‍💻" {
+		t.Errorf("Truncate = %q", got)
+	}
+	if got := Truncate(s, 0); got != "" {
+		t.Errorf("Truncate(0) = %q", got)
+	}
+	if got := Truncate(s, 10); got != s {
+		t.Errorf("Truncate(10) = %q", got)
+	}
+}
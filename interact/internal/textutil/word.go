@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+package textutil
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// The word helpers below work on byte offsets and split text with Unicode word
+// segmentation (UAX #29), so "don't", "3.14" and "日本語" behave as words and
+// runs of spaces or punctuation are skipped.
+
+// WordSegments returns the byte offsets of the word segment boundaries in s,
+// starting with 0 and ending with len(s).
+func WordSegments(s string) []int {
+	bounds := []int{0}
+	state := -1
+	rest := s
+	offset := 0
+	for rest != "" {
+		var segment string
+		segment, rest, state = uniseg.FirstWordInString(rest, state)
+		offset += len(segment)
+		bounds = append(bounds, offset)
+	}
+	return bounds
+}
+
+// isWord reports whether a segment is a word rather than spaces or punctuation.
+func isWord(segment string) bool {
+	for _, r := range segment {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return true
+		}
+	}
+	return false
+}
+
+// PrevWordStart returns the start of the word before offset, skipping any
+// spaces and punctuation in between, or 0 if there is none.
+func PrevWordStart(s string, offset int) int {
+	bounds := WordSegments(s)
+	for i := len(bounds) - 2; i >= 0; i-- {
+		if bounds[i] < offset && isWord(s[bounds[i]:bounds[i+1]]) {
+			return bounds[i]
+		}
+	}
+	return 0
+}
+
+// NextWordEnd returns the end of the word after offset, skipping any spaces
+// and punctuation in between, or len(s) if there is none.
+func NextWordEnd(s string, offset int) int {
+	bounds := WordSegments(s)
+	for i := 1; i < len(bounds); i++ {
+		if bounds[i] > offset && isWord(s[bounds[i-1]:bounds[i]]) {
+			return bounds[i]
+		}
+	}
+	return len(s)
+}
+
+// WordAt returns the byte range of the segment containing offset: a word, a
+// run of spaces or a punctuation mark. An offset at the end of s selects the
+// last segment.
+func WordAt(s string, offset int) (start, end int) {
+	if s == "" {
+		return 0, 0
+	}
+	if offset >= len(s) {
+		_, size := utf8.DecodeLastRuneInString(s)
+		offset = len(s) - size
+	}
+	bounds := WordSegments(s)
+	for i := 1; i < len(bounds); i++ {
+		if bounds[i] > offset {
+			return bounds[i-1], bounds[i]
+		}
+	}
+	return 0, len(s)
+}
@@ -0,0 +1,40 @@
+package textutil
+
+import "testing"
+
+func TestPrevWordStartAndNextWordEnd(t *testing.T) {
+	const s = "say  \"don't\", 3.14 café!"
+	// Word starts: say=0 don't=6 3.14=14 café=19; ends: 3, 11, 18, 24.
+	prev := []struct{ offset, want int }{{0, 0}, {2, 0}, {3, 0}, {6, 0}, {7, 6}, {13, 6}, {14, 6}, {15, 14}, {len(s), 19}}
+	for _, tt := range prev {
+		if got := PrevWordStart(s, tt.offset); got != tt.want {
+			t.Errorf("PrevWordStart(%d) = %d, want %d", tt.offset, got, tt.want)
+		}
+	}
+	next := []struct{ offset, want int }{{0, 3}, {3, 11}, {7, 11}, {11, 18}, {18, 24}, {24, len(s)}, {len(s), len(s)}}
+	for _, tt := range next {
+		if got := NextWordEnd(s, tt.offset); got != tt.want {
+			t.Errorf("NextWordEnd(%d) = %d, want %d", tt.offset, got, tt.want)
+		}
+	}
+}
+
+func TestWordAt(t *testing.T) {
+	const s = "hello,  wörld"
+	tests := []struct{ offset, start, end int }{
+		{0, 0, 5},
+		{4, 0, 5},
+		{5, 5, 6},           // the comma on its own
+		{6, 6, 8},           // both spaces
+		{9, 8, len(s)},      // inside "wörld"
+		{len(s), 8, len(s)}, // the end selects the last word
+	}
+	for _, tt := range tests {
+		if start, end := WordAt(s, tt.offset); start != tt.start || end != tt.end {
+			t.Errorf("WordAt(%d) = %d-%d, want %d-%d", tt.offset, start, end, tt.start, tt.end)
+		}
+	}
+	if start, end := WordAt("", 0); start != 0 || end != 0 {
+		t.Errorf("WordAt on empty text = %d-%d", start, end)
+	}
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: MIT
+package textutil
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// Width returns the advance width of s in pixels. Unlike the ink bounds it
+// includes trailing spaces, so it is the right measure for caret positions.
+func Width(face font.Face, s string) float32 {
+	if face == nil {
+		return 0
+	}
+	return float32(font.MeasureString(face, s)) / 64
+}
+
+// CaretPositions returns the x offset, relative to the start of s, of every
+// grapheme boundary in s: element i is where a caret before the i-th grapheme
+// cluster is drawn. It walks the glyph advances and kerning once, so it gives
+// the same result as measuring every prefix without the quadratic cost.
+func CaretPositions(face font.Face, s string) []float32 {
+	positions := []float32{0}
+	var advance fixed.Int26_6
+	prev := rune(-1)
+	state := -1
+	rest := s
+	for rest != "" {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		if face != nil {
+			for _, r := range cluster {
+				if prev >= 0 {
+					advance += face.Kern(prev, r)
+				}
+				a, _ := face.GlyphAdvance(r)
+				advance += a
+				prev = r
+			}
+		}
+		positions = append(positions, float32(advance)/64)
+	}
+	return positions
+}
+
+// Nearest returns the index of the position closest to x.
+func Nearest(positions []float32, x float32) int {
+	best := 0
+	for i, p := range positions {
+		if abs32(x-p) < abs32(x-positions[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Line is one visual line of wrapped text as byte offsets into the text.
+// End excludes the newline that ends a hard line.
+type Line struct {
+	Start, End int
+}
+
+// Wrap splits s into lines at every newline and, when face is non-nil and
+// width is positive, wherever a line would grow wider than width. Lines break
+// at Unicode line break opportunities (after spaces, between CJK characters
+// and so on); a word wider than the whole line is split between grapheme
+// clusters. Trailing spaces are allowed to overflow the width.
+func Wrap(face font.Face, s string, width float32) []Line {
+	var lines []Line
+	start := 0
+	for {
+		end := len(s)
+		if i := strings.IndexByte(s[start:], '\n'); i >= 0 {
+			end = start + i
+		}
+		lines = wrapHardLine(lines, face, s, start, end, width)
+		if end == len(s) {
+			return lines
+		}
+		start = end + 1
+	}
+}
+
+func wrapHardLine(lines []Line, face font.Face, s string, start, end int, width float32) []Line {
+	if face == nil || width <= 0 {
+		return append(lines, Line{start, end})
+	}
+
+	lineStart := start
+	x := float32(0)
+	pos := start
+	state := -1
+	for pos < end {
+		var segment string
+		segment, _, _, state = uniseg.FirstLineSegmentInString(s[pos:end], state)
+		word := strings.TrimRightFunc(segment, unicode.IsSpace)
+		wordWidth := Width(face, word)
+
+		if x > 0 && x+wordWidth > width {
+			lines = append(lines, Line{lineStart, pos})
+			lineStart = pos
+			x = 0
+		}
+
+		if wordWidth > width {
+			// Too long for any line: break between grapheme clusters.
+			p := pos
+			rest := word
+			graphemeState := -1
+			for rest != "" {
+				var cluster string
+				cluster, rest, _, graphemeState = uniseg.FirstGraphemeClusterInString(rest, graphemeState)
+				clusterWidth := Width(face, cluster)
+				if x > 0 && x+clusterWidth > width {
+					lines = append(lines, Line{lineStart, p})
+					lineStart = p
+					x = 0
+				}
+				x += clusterWidth
+				p += len(cluster)
+			}
+			x += Width(face, segment[len(word):])
+		} else {
+			x += Width(face, segment)
+		}
+		pos += len(segment)
+	}
+	return append(lines, Line{lineStart, end})
+}
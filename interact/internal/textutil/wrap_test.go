@@ -0,0 +1,89 @@
+package textutil
+
+import (
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/testutil"
+	"golang.org/x/image/font/basicfont"
+)
+
+// basicfont.Face7x13 advances every character by 7 pixels.
+const charWidth = 7
+
+func wrapped(s string, chars int) []string {
+	var out []string
+	for _, l := range Wrap(basicfont.Face7x13, s, float32(chars*charWidth)) {
+		out = append(out, s[l.Start:l.End])
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		chars int
+		want  []string
+	}{
+		{"fits", "hello", 10, []string{"hello"}},
+		{"empty", "", 10, []string{""}},
+		{"words", "the quick brown fox", 10, []string{"the quick ", "brown fox"}},
+		{"newlines", "a\n\nb", 10, []string{"a", "", "b"}},
+		{"trailing newline", "a\n", 10, []string{"a", ""}},
+		{"long word", "abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"long word after text", "ab abcdefgh", 4, []string{"ab ", "abcd", "efgh"}},
+		{"spaces overflow", "abcd    efg", 4, []string{"abcd    ", "efg"}},
+	}
+	for _, tt := range tests {
+		if got := wrapped(tt.s, tt.chars); !equal(got, tt.want) {
+			t.Errorf("%s: Wrap(%q, %d) = %q, want %q", tt.name, tt.s, tt.chars, got, tt.want)
+		}
+	}
+}
+
+func TestWrapWithoutFace(t *testing.T) {
+	lines := Wrap(nil, "one two\nthree", 7)
+	if len(lines) != 2 || lines[1].Start != 8 || lines[1].End != 13 {
+		t.Fatalf("got %v", lines)
+	}
+}
+
+func TestCaretPositionsMatchMeasuredPrefixes(t *testing.T) {
+	face := testutil.GoRegular(20)
+	for _, s := range []string{"", "AVATAR Wi", "zażółć gęślą", "é👩‍💻x"} {
+		positions := CaretPositions(face, s)
+		bounds := Boundaries(s)
+		if len(positions) != len(bounds) {
+			t.Fatalf("%q: %d positions for %d boundaries", s, len(positions), len(bounds))
+		}
+		for i, b := range bounds {
+			if want := Width(face, s[:b]); positions[i] != want {
+				t.Errorf("%q: position %d = %v, want %v", s, i, positions[i], want)
+			}
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	positions := []float32{0, 10, 14, 30}
+	for _, tt := range []struct {
+		x    float32
+		want int
+	}{{-5, 0}, {4, 0}, {6, 1}, {12.5, 2}, {21, 2}, {23, 3}, {100, 3}} {
+		if got := Nearest(positions, tt.x); got != tt.want {
+			t.Errorf("Nearest(%v) = %d, want %d", tt.x, got, tt.want)
+		}
+	}
+}
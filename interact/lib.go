@@ -5,8 +5,17 @@ import (
 
 	"github.com/OrtheSnowJames/ebiten-interactive/interact/button"
 	"github.com/OrtheSnowJames/ebiten-interactive/interact/clip"
-	"github.com/OrtheSnowJames/ebiten-interactive/interact/textfield"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/clock"
 	"github.com/OrtheSnowJames/ebiten-interactive/interact/colorscheme"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/dropdown"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/listbox"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/overlay"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/radio"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/slider"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/textarea"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/textfield"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/toggle"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
@@ -16,9 +25,63 @@ type InteractiveObject interface {
 	Draw(screen *ebiten.Image)
 }
 
+// Input is the source widgets read mouse, keyboard and touch state from.
+type Input = input.Input
+
+// SetInput replaces the input source used by every widget without its own Input.
+// Passing nil restores the default ebiten-backed input.
+func SetInput(in Input) {
+	input.SetDefault(in)
+}
+
+// EbitenInput returns the default input source, which reads from ebiten directly.
+// Wrap it to remap or filter inputs before passing the result to SetInput.
+func EbitenInput() Input {
+	return input.Ebiten()
+}
+
+// Keymap maps the modifiers used by editing shortcuts to physical keys.
+type Keymap = input.Keymap
+
+// SetKeymap replaces the keymap used by every widget without its own Keymap.
+func SetKeymap(k Keymap) {
+	input.SetDefaultKeymap(k)
+}
+
+// PCKeymap uses Control for shortcuts.
+func PCKeymap() Keymap {
+	return input.PCKeymap()
+}
+
+// MacKeymap uses Command for shortcuts.
+func MacKeymap() Keymap {
+	return input.MacKeymap()
+}
+
+// KeyRepeat is the delay and interval at which held keys repeat.
+type KeyRepeat = input.KeyRepeat
+
+// SetKeyRepeat replaces the key repeat used by every widget without its own.
+func SetKeyRepeat(r KeyRepeat) {
+	input.SetDefaultKeyRepeat(r)
+}
+
+// Clock tells widgets how long each frame lasts.
+type Clock = clock.Clock
+
+// SetClock replaces the clock used by every widget without its own.
+// Passing nil restores the default, which follows ebiten's TPS.
+func SetClock(c Clock) {
+	clock.SetDefault(c)
+}
+
 func SetDefaultFont(face font.Face) {
 	button.DefaultFont = face
 	textfield.DefaultFont = face
+	textarea.DefaultFont = face
+	radio.DefaultFont = face
+	slider.DefaultFont = face
+	listbox.DefaultFont = face
 }
 
 func NewButton(x, y, width, height float32, text string) *button.Button {
@@ -45,6 +108,58 @@ func NewNormalButton(x, y, width, height float32, text string) *button.Button {
 	return btn
 }
 
+// NewIconButton creates a button showing an icon, placed relative to the label.
+// Pass button.IconOnly with an empty label for toolbar buttons.
+func NewIconButton(x, y, width, height float32, icon *ebiten.Image, label string, placement button.IconPlacement) *button.Button {
+	b := button.NewButton(x, y, width, height, label)
+	b.SetIcon(icon, placement)
+	return b
+}
+
+// NewCheckbox creates an unchecked checkbox with a label to its right.
+func NewCheckbox(x, y, width, height float32, label string) *toggle.Toggle {
+	return toggle.NewCheckbox(x, y, width, height, label)
+}
+
+// NewSwitch creates a sliding on/off switch, initially off, with a label to its right.
+func NewSwitch(x, y, width, height float32, label string) *toggle.Toggle {
+	return toggle.NewSwitch(x, y, width, height, label)
+}
+
+// NewRadioGroup creates a column of radio buttons, one row of the given height
+// per option, with nothing selected.
+func NewRadioGroup(x, y, width, rowHeight float32, options ...radio.Option) *radio.RadioGroup {
+	return radio.New(x, y, width, rowHeight, options...)
+}
+
+// NewSlider creates a horizontal slider from min to max, starting at min.
+func NewSlider(x, y, width, height float32, min, max float64) *slider.Slider {
+	return slider.New(x, y, width, height, min, max)
+}
+
+// NewDropdown creates a dropdown of items with nothing selected. Its list is
+// drawn by DrawOverlays.
+func NewDropdown(x, y, width, height float32, items ...string) *dropdown.Dropdown {
+	return dropdown.New(x, y, width, height, items...)
+}
+
+// NewComboBox creates an editable dropdown whose list is filtered by the text
+// typed into it.
+func NewComboBox(x, y, width, height float32, items ...string) *dropdown.Dropdown {
+	return dropdown.NewEditable(x, y, width, height, items...)
+}
+
+// NewListBox creates a scrolling list of the items in source, one row of
+// itemHeight per item, with nothing selected.
+func NewListBox(x, y, width, height, itemHeight float32, source listbox.DataSource) *listbox.ListBox {
+	return listbox.New(x, y, width, height, itemHeight, source)
+}
+
+// NewStringListBox creates a scrolling list of text items.
+func NewStringListBox(x, y, width, height, itemHeight float32, items ...string) *listbox.ListBox {
+	return listbox.NewStringList(x, y, width, height, itemHeight, items...)
+}
+
 func NewTextField(x, y, width, height float32, maxLength int) *textfield.TextField {
 	return textfield.NewTextField(x, y, width, height, maxLength)
 }
@@ -55,6 +170,28 @@ func NewTextFieldWithPlaceholder(x, y, width, height float32, maxLength int, pla
 	return tf
 }
 
+// NewPasswordField creates a masked text field for passwords and other secrets.
+func NewPasswordField(x, y, width, height float32, maxLength int) *textfield.TextField {
+	tf := textfield.NewTextField(x, y, width, height, maxLength)
+	tf.SetMasked(true)
+	return tf
+}
+
+// NewRevealToggle creates a "show password" eye button for a masked field.
+func NewRevealToggle(field *textfield.TextField, x, y, width, height float32) *textfield.RevealToggle {
+	return textfield.NewRevealToggle(field, x, y, width, height)
+}
+
+func NewTextArea(x, y, width, height float32, maxLength int) *textarea.TextArea {
+	return textarea.NewTextArea(x, y, width, height, maxLength)
+}
+
+func NewTextAreaWithPlaceholder(x, y, width, height float32, maxLength int, placeholder string) *textarea.TextArea {
+	ta := textarea.NewTextArea(x, y, width, height, maxLength)
+	ta.SetPlaceholder(placeholder)
+	return ta
+}
+
 func CopyClip(text string) error {
 	return clip.CopyClip(text)
 }
@@ -78,6 +215,12 @@ func DrawAll(screen *ebiten.Image, objects ...InteractiveObject) {
 	}
 }
 
+// DrawOverlays draws popups, such as open dropdown lists, on top of everything
+// else. Call it once at the end of your Draw, after drawing all widgets.
+func DrawOverlays(screen *ebiten.Image) {
+	overlay.Draw(screen)
+}
+
 // Can update multiple objects at once instead of doing .update .update ..., call like this: interact.UpdateAll(obj1, obj2, obj3)
 func UpdateAll(objects ...InteractiveObject) {
 	for _, obj := range objects {
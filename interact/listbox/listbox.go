@@ -0,0 +1,669 @@
+// SPDX-License-Identifier: MIT
+
+// Package listbox provides a scrolling list of items drawn from a data
+// source, for long lists such as save slots or a server browser.
+package listbox
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/clock"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/colorscheme"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/draw"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/paint"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/overlay"
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+)
+
+// Rect defines a rectangle with float32 coordinates.
+type Rect struct {
+	X, Y, W, H float32
+}
+
+func NewRect(x, y, w, h float32) Rect {
+	return Rect{x, y, w, h}
+}
+
+func pointInRect(x, y float32, r Rect) bool {
+	return x >= r.X && x <= r.X+r.W && y >= r.Y && y <= r.Y+r.H
+}
+
+// DefaultFont is a package-level font face used for drawing text.
+// Set this to a valid font.Face during initialization.
+var DefaultFont font.Face
+
+const (
+	// scrollbarWidth is the width reserved for the vertical scrollbar on the right.
+	scrollbarWidth = float32(8)
+	// minThumbHeight keeps the scrollbar thumb grabbable for very long lists.
+	minThumbHeight = float32(16)
+	// wheelItems is how many items one wheel step scrolls.
+	wheelItems = 3
+	// doubleClickTime is the most seconds between two clicks on an item for
+	// them to activate it.
+	doubleClickTime = 0.4
+)
+
+// ListBox is a scrolling list of fixed-height rows. Its items come from a
+// DataSource and only the rows on screen are drawn, so lists of thousands of
+// items cost no more than short ones.
+//
+// Clicking an item selects it. With MultiSelect set, clicking with the
+// keymap's shortcut modifier (Control, or Command on macOS) adds or removes
+// an item and clicking with Shift selects the range from the last item
+// clicked. After a click the list box is active: the arrow keys, Page Up,
+// Page Down, Home and End move the selection, extending it with Shift, and
+// Space toggles the current item of a multi-select list. Double-clicking an
+// item or pressing Enter activates it. The mouse wheel and the scrollbar
+// scroll the list.
+type ListBox struct {
+	Bounds          Rect
+	Source          DataSource
+	ItemHeight      float32
+	MultiSelect     bool
+	BackgroundColor color.RGBA
+	HoverColor      color.RGBA
+	SelectedColor   color.RGBA
+	BorderColor     color.RGBA
+	ScrollbarColor  color.RGBA
+	ScrollY         float32 // How far the list is scrolled down, in pixels
+	Enabled         bool
+	Invisible       bool
+	IsActive        bool             // Keys move the selection
+	Input           input.Input      // Input source; nil uses input.Default()
+	Keymap          *input.Keymap    // Shortcut modifiers; nil uses input.DefaultKeymap()
+	KeyRepeat       *input.KeyRepeat // Timing of held keys; nil uses input.DefaultKeyRepeat()
+	Clock           clock.Clock      // Frame timing for key repeat and double-clicks; nil uses clock.Default()
+
+	// OnSelectionChange is called from Update when the user changes the
+	// selection, with the selected indices in order.
+	OnSelectionChange func(selected []int)
+	// OnActivate is called from Update when an item is double-clicked or
+	// Enter is pressed on it.
+	OnActivate func(index int)
+
+	selected          map[int]bool
+	changed           bool // The selection changed during this Update
+	cursor            int  // The item the keyboard is on, or -1
+	anchor            int  // The end of a Shift range that stays put, or -1
+	hovered           int
+	lastClick         int
+	clickTimer        float32
+	draggingScrollbar bool
+	scrollGrabOffset  float32
+	repeater          input.Repeater
+}
+
+// New creates a list box of the items in source, one row of itemHeight per
+// item, with nothing selected.
+func New(x, y, width, height, itemHeight float32, source DataSource) *ListBox {
+	return &ListBox{
+		Bounds:          NewRect(x, y, width, height),
+		Source:          source,
+		ItemHeight:      itemHeight,
+		BackgroundColor: color.RGBA{R: 255, G: 255, B: 255, A: 255}, // White
+		HoverColor:      color.RGBA{R: 235, G: 235, B: 235, A: 255},
+		SelectedColor:   color.RGBA{R: 173, G: 214, B: 255, A: 255}, // Light blue
+		BorderColor:     color.RGBA{R: 0, G: 0, B: 0, A: 255},       // Black
+		ScrollbarColor:  color.RGBA{R: 160, G: 160, B: 160, A: 255}, // Gray
+		Enabled:         true,
+		selected:        map[int]bool{},
+		cursor:          -1,
+		anchor:          -1,
+		hovered:         -1,
+		lastClick:       -1,
+	}
+}
+
+// NewStringList creates a list box of text items.
+func NewStringList(x, y, width, height, itemHeight float32, items ...string) *ListBox {
+	return New(x, y, width, height, itemHeight, NewStrings(items...))
+}
+
+// SetSource replaces the data source, clearing the selection and scrolling
+// back to the top.
+func (lb *ListBox) SetSource(source DataSource) {
+	lb.Source = source
+	clear(lb.selected)
+	lb.cursor, lb.anchor, lb.lastClick = -1, -1, -1
+	lb.ScrollY = 0
+}
+
+// SetMultiSelect sets whether several items can be selected at once. Turning
+// it off keeps only the current item selected.
+func (lb *ListBox) SetMultiSelect(multi bool) {
+	lb.MultiSelect = multi
+	if !multi && len(lb.selected) > 1 {
+		keep := lb.Selected()
+		if lb.selected[lb.cursor] {
+			keep = lb.cursor
+		}
+		clear(lb.selected)
+		lb.selected[keep] = true
+	}
+}
+
+func (lb *ListBox) SetItemHeight(height float32) {
+	lb.ItemHeight = height
+	lb.ScrollBy(0)
+}
+
+func (lb *ListBox) SetColors(background, hover, selected, border, scrollbar color.RGBA) {
+	lb.BackgroundColor = background
+	lb.HoverColor = hover
+	lb.SelectedColor = selected
+	lb.BorderColor = border
+	lb.ScrollbarColor = scrollbar
+}
+
+// SetColorScheme styles the list box to match buttons with the same scheme:
+// selected rows are drawn in the pressed color. A source with a
+// SetColorScheme method of its own, such as Strings, is styled too.
+func (lb *ListBox) SetColorScheme(scheme colorscheme.ColorScheme) {
+	lb.SetColors(scheme.Background, scheme.Hover, scheme.Pressed, scheme.Border, scheme.Border)
+	if s, ok := lb.Source.(interface{ SetColorScheme(colorscheme.ColorScheme) }); ok {
+		s.SetColorScheme(scheme)
+	}
+}
+
+func (lb *ListBox) SetEnabled(enabled bool) {
+	lb.Enabled = enabled
+}
+
+func (lb *ListBox) SetInvisible(invisible bool) {
+	lb.Invisible = invisible
+}
+
+// SetInput sets the input source the list box reads from. Passing nil uses input.Default().
+func (lb *ListBox) SetInput(in input.Input) {
+	lb.Input = in
+}
+
+// SetKeymap sets the modifier keys used for shortcuts. Passing nil uses input.DefaultKeymap().
+func (lb *ListBox) SetKeymap(keymap *input.Keymap) {
+	lb.Keymap = keymap
+}
+
+// SetKeyRepeat sets the timing of held keys. Passing nil uses input.DefaultKeyRepeat().
+func (lb *ListBox) SetKeyRepeat(repeat *input.KeyRepeat) {
+	lb.KeyRepeat = repeat
+}
+
+// SetClock sets the clock key repeat and double-clicks are timed by. Passing nil uses clock.Default().
+func (lb *ListBox) SetClock(c clock.Clock) {
+	lb.Clock = c
+}
+
+// Activate lets the keys move the selection.
+func (lb *ListBox) Activate() {
+	lb.IsActive = true
+}
+
+// Deactivate stops the keys moving the selection.
+func (lb *ListBox) Deactivate() {
+	lb.IsActive = false
+}
+
+// Len returns the number of items in the source.
+func (lb *ListBox) Len() int {
+	if lb.Source == nil {
+		return 0
+	}
+	return lb.Source.Len()
+}
+
+// Selected returns the index of the first selected item, or -1 if none is.
+func (lb *ListBox) Selected() int {
+	first := -1
+	for i := range lb.selected {
+		if first < 0 || i < first {
+			first = i
+		}
+	}
+	return first
+}
+
+// SelectedIndices returns the indices of the selected items in order.
+func (lb *ListBox) SelectedIndices() []int {
+	indices := make([]int, 0, len(lb.selected))
+	for i := range lb.selected {
+		indices = append(indices, i)
+	}
+	slices.Sort(indices)
+	return indices
+}
+
+// IsSelected reports whether the item at index is selected.
+func (lb *ListBox) IsSelected(index int) bool {
+	return lb.selected[index]
+}
+
+// Current returns the index of the item the keyboard is on, or -1.
+func (lb *ListBox) Current() int {
+	return lb.cursor
+}
+
+// SetSelected selects only the item at index and scrolls it into view,
+// without calling OnSelectionChange. An index out of range clears the selection.
+func (lb *ListBox) SetSelected(index int) {
+	clear(lb.selected)
+	if index < 0 || index >= lb.Len() {
+		lb.cursor, lb.anchor = -1, -1
+		return
+	}
+	lb.selected[index] = true
+	lb.cursor, lb.anchor = index, index
+	lb.EnsureVisible(index)
+}
+
+// SetItemSelected selects or deselects the item at index without calling
+// OnSelectionChange. Unless MultiSelect is set, selecting an item deselects
+// the others.
+func (lb *ListBox) SetItemSelected(index int, selected bool) {
+	if index < 0 || index >= lb.Len() {
+		return
+	}
+	if !selected {
+		delete(lb.selected, index)
+		return
+	}
+	if !lb.MultiSelect {
+		clear(lb.selected)
+	}
+	lb.selected[index] = true
+}
+
+// SelectAll selects every item of a multi-select list box without calling
+// OnSelectionChange.
+func (lb *ListBox) SelectAll() {
+	if !lb.MultiSelect {
+		return
+	}
+	for i := range lb.Len() {
+		lb.selected[i] = true
+	}
+}
+
+// ClearSelection deselects every item without calling OnSelectionChange.
+func (lb *ListBox) ClearSelection() {
+	clear(lb.selected)
+}
+
+// set selects or deselects an item, noting a change for OnSelectionChange.
+func (lb *ListBox) set(index int, on bool) {
+	if lb.selected[index] == on {
+		return
+	}
+	if on {
+		lb.selected[index] = true
+	} else {
+		delete(lb.selected, index)
+	}
+	lb.changed = true
+}
+
+// selectOnly selects the item at index and deselects the rest.
+func (lb *ListBox) selectOnly(index int) {
+	for i := range lb.selected {
+		if i != index {
+			lb.set(i, false)
+		}
+	}
+	lb.set(index, true)
+}
+
+// selectRange selects the items from the anchor to index and deselects the rest.
+func (lb *ListBox) selectRange(index int) {
+	if lb.anchor < 0 || lb.anchor >= lb.Len() {
+		lb.anchor = index
+	}
+	lo, hi := min(lb.anchor, index), max(lb.anchor, index)
+	for i := range lb.selected {
+		if i < lo || i > hi {
+			lb.set(i, false)
+		}
+	}
+	for i := lo; i <= hi; i++ {
+		lb.set(i, true)
+	}
+}
+
+// viewRect returns the area rows are drawn in: inside the border, left of
+// the scrollbar when there is one.
+func (lb *ListBox) viewRect() Rect {
+	view := NewRect(lb.Bounds.X+1, lb.Bounds.Y+1, lb.Bounds.W-2, lb.Bounds.H-2)
+	if lb.contentHeight() > view.H {
+		view.W -= scrollbarWidth + 2
+	}
+	return view
+}
+
+// contentHeight returns the height of all the rows together.
+func (lb *ListBox) contentHeight() float32 {
+	return float32(lb.Len()) * max(lb.ItemHeight, 0)
+}
+
+func (lb *ListBox) maxScroll() float32 {
+	return max(0, lb.contentHeight()-(lb.Bounds.H-2))
+}
+
+// ScrollBy scrolls the list by dy pixels, keeping it within the items.
+func (lb *ListBox) ScrollBy(dy float32) {
+	lb.ScrollY = max(0, min(lb.ScrollY+dy, lb.maxScroll()))
+}
+
+// EnsureVisible scrolls the least distance that shows the whole item at index.
+func (lb *ListBox) EnsureVisible(index int) {
+	top := float32(index) * lb.ItemHeight
+	view := lb.viewRect().H
+	if top < lb.ScrollY {
+		lb.ScrollY = top
+	} else if top+lb.ItemHeight > lb.ScrollY+view {
+		lb.ScrollY = top + lb.ItemHeight - view
+	}
+	lb.ScrollBy(0)
+}
+
+// visibleRows returns how many whole rows fit in the view, at least one.
+func (lb *ListBox) visibleRows() int {
+	if lb.ItemHeight <= 0 {
+		return 1
+	}
+	return max(1, int(lb.viewRect().H/lb.ItemHeight))
+}
+
+// indexAt returns the index of the item at x, y, or -1 if there is none.
+func (lb *ListBox) indexAt(x, y float32) int {
+	view := lb.viewRect()
+	if !pointInRect(x, y, view) || lb.ItemHeight <= 0 {
+		return -1
+	}
+	index := int((y - view.Y + lb.ScrollY) / lb.ItemHeight)
+	if index >= lb.Len() {
+		return -1
+	}
+	return index
+}
+
+// scrollbarRects returns the scrollbar track and thumb, or false when all
+// of the items fit and no scrollbar is shown.
+func (lb *ListBox) scrollbarRects() (track, thumb Rect, ok bool) {
+	content := lb.contentHeight()
+	view := lb.Bounds.H - 2
+	if content <= view {
+		return Rect{}, Rect{}, false
+	}
+	track = NewRect(lb.Bounds.X+lb.Bounds.W-scrollbarWidth-2, lb.Bounds.Y+2, scrollbarWidth, lb.Bounds.H-4)
+	thumbH := min(track.H, max(minThumbHeight, track.H*view/content))
+	thumbY := track.Y + (track.H-thumbH)*lb.ScrollY/lb.maxScroll()
+	thumb = NewRect(track.X, thumbY, track.W, thumbH)
+	return track, thumb, true
+}
+
+// dragScrollbar scrolls so the thumb follows the pointer at y.
+func (lb *ListBox) dragScrollbar(y float32) {
+	track, thumb, ok := lb.scrollbarRects()
+	if !ok {
+		return
+	}
+	free := track.H - thumb.H
+	if free <= 0 {
+		return
+	}
+	lb.ScrollY = max(0, min((y-lb.scrollGrabOffset-track.Y)/free*lb.maxScroll(), lb.maxScroll()))
+}
+
+// forgetRemoved drops the selection and cursor past the end of a source that
+// has shrunk since the last frame.
+func (lb *ListBox) forgetRemoved() {
+	n := lb.Len()
+	for i := range lb.selected {
+		if i >= n {
+			delete(lb.selected, i)
+		}
+	}
+	if lb.cursor >= n {
+		lb.cursor = n - 1
+	}
+	if lb.anchor >= n {
+		lb.anchor = -1
+	}
+	lb.ScrollBy(0)
+}
+
+// Update should be called every frame.
+func (lb *ListBox) Update() {
+	lb.forgetRemoved()
+	if !lb.Enabled {
+		lb.hovered, lb.IsActive, lb.draggingScrollbar = -1, false, false
+		return
+	}
+
+	in := input.Or(lb.Input)
+	dt := clock.Or(lb.Clock).Delta()
+	lb.clickTimer += dt
+	mx, my := in.CursorPosition()
+	x, y := float32(mx), float32(my)
+	over := pointInRect(x, y, lb.Bounds) && !overlay.Blocks(lb, x, y)
+	lb.hovered = -1
+	if over && !lb.draggingScrollbar {
+		lb.hovered = lb.indexAt(x, y)
+	}
+
+	lb.changed = false
+	lb.handleMouse(in, x, y, over)
+
+	// Scroll with the mouse wheel while the cursor is over the list box.
+	if _, dy := in.Wheel(); dy != 0 && over {
+		lb.ScrollBy(-float32(dy) * wheelItems * lb.ItemHeight)
+	}
+
+	lb.repeater.Update(in, input.KeyRepeatOr(lb.KeyRepeat), dt)
+	if lb.IsActive {
+		lb.handleKeys(in)
+	}
+
+	if lb.changed && lb.OnSelectionChange != nil {
+		lb.OnSelectionChange(lb.SelectedIndices())
+	}
+}
+
+// handleMouse activates the list box on click, selects and activates clicked
+// items and drags the scrollbar thumb.
+func (lb *ListBox) handleMouse(in input.Input, x, y float32, over bool) {
+	if in.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		lb.IsActive = over
+		if !over {
+			return
+		}
+
+		if track, thumb, ok := lb.scrollbarRects(); ok && x >= track.X {
+			lb.draggingScrollbar = true
+			if pointInRect(x, y, thumb) {
+				lb.scrollGrabOffset = y - thumb.Y
+			} else {
+				// Clicking the track centers the thumb on the pointer.
+				lb.scrollGrabOffset = thumb.H / 2
+				lb.dragScrollbar(y)
+			}
+			return
+		}
+
+		index := lb.indexAt(x, y)
+		if index < 0 {
+			return
+		}
+		double := index == lb.lastClick && lb.clickTimer <= doubleClickTime
+		lb.clickTimer = 0
+		lb.lastClick = index
+		lb.click(index, in.IsKeyPressed(ebiten.KeyShift), in.IsKeyPressed(input.KeymapOr(lb.Keymap).Shortcut))
+		if double {
+			// A third click starts a new double-click rather than activating again.
+			lb.lastClick = -1
+			lb.activate(index)
+		}
+		return
+	}
+
+	if !in.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		lb.draggingScrollbar = false
+		return
+	}
+	if lb.draggingScrollbar {
+		lb.dragScrollbar(y)
+	}
+}
+
+// click selects the item at index: alone, as the end of a range from the
+// anchor with Shift, or toggled with the shortcut modifier.
+func (lb *ListBox) click(index int, shift, shortcut bool) {
+	lb.cursor = index
+	switch {
+	case lb.MultiSelect && shift:
+		lb.selectRange(index)
+	case lb.MultiSelect && shortcut:
+		lb.set(index, !lb.selected[index])
+		lb.anchor = index
+	default:
+		lb.selectOnly(index)
+		lb.anchor = index
+	}
+	lb.EnsureVisible(index)
+}
+
+// handleKeys moves the selection, toggles and activates items and selects all.
+func (lb *ListBox) handleKeys(in input.Input) {
+	keymap := input.KeymapOr(lb.Keymap)
+	shift := in.IsKeyPressed(ebiten.KeyShift)
+	shortcut := in.IsKeyPressed(keymap.Shortcut)
+
+	page := lb.visibleRows()
+	moves := []struct {
+		key ebiten.Key
+		by  int
+	}{
+		{ebiten.KeyUp, -1},
+		{ebiten.KeyDown, 1},
+		{ebiten.KeyPageUp, -page},
+		{ebiten.KeyPageDown, page},
+	}
+	for _, m := range moves {
+		for range lb.repeater.Presses(in, m.key) {
+			lb.moveTo(lb.cursor+m.by, shift, shortcut)
+		}
+	}
+	if in.IsKeyJustPressed(ebiten.KeyHome) {
+		lb.moveTo(0, shift, shortcut)
+	}
+	if in.IsKeyJustPressed(ebiten.KeyEnd) {
+		lb.moveTo(lb.Len()-1, shift, shortcut)
+	}
+
+	switch {
+	case in.IsKeyJustPressed(ebiten.KeySpace) && lb.cursor >= 0:
+		if lb.MultiSelect {
+			lb.set(lb.cursor, !lb.selected[lb.cursor])
+			lb.anchor = lb.cursor
+		} else {
+			lb.selectOnly(lb.cursor)
+		}
+	case in.IsKeyJustPressed(ebiten.KeyEnter) || in.IsKeyJustPressed(ebiten.KeyNumpadEnter):
+		if lb.cursor >= 0 {
+			lb.activate(lb.cursor)
+		}
+	case in.IsKeyJustPressed(ebiten.KeyA) && shortcut && lb.MultiSelect:
+		for i := range lb.Len() {
+			lb.set(i, true)
+		}
+	case in.IsKeyJustPressed(ebiten.KeyEscape):
+		lb.IsActive = false
+	}
+}
+
+// moveTo moves the keyboard to the item at index, clamped to the items, and
+// scrolls it into view. It becomes the only selected item, unless a
+// multi-select list is extending the range with Shift or, with the shortcut
+// modifier held, moving without changing the selection.
+func (lb *ListBox) moveTo(index int, extend, keep bool) {
+	n := lb.Len()
+	if n == 0 {
+		return
+	}
+	index = max(0, min(index, n-1))
+	lb.cursor = index
+	switch {
+	case lb.MultiSelect && extend:
+		lb.selectRange(index)
+	case lb.MultiSelect && keep:
+	default:
+		lb.selectOnly(index)
+		lb.anchor = index
+	}
+	lb.EnsureVisible(index)
+}
+
+func (lb *ListBox) activate(index int) {
+	if lb.OnActivate != nil {
+		lb.OnActivate(index)
+	}
+}
+
+// Draw draws the background, the rows on screen, the scrollbar and the border.
+func (lb *ListBox) Draw(screen *ebiten.Image) {
+	if lb.Invisible {
+		return
+	}
+	draw.FillRect(screen, lb.Bounds.X, lb.Bounds.Y, lb.Bounds.W, lb.Bounds.H, lb.color(lb.BackgroundColor))
+
+	view := lb.viewRect()
+	if n := lb.Len(); n > 0 && lb.ItemHeight > 0 && lb.Source != nil {
+		clip := image.Rect(int(view.X), int(view.Y), int(math.Ceil(float64(view.X+view.W))), int(math.Ceil(float64(view.Y+view.H))))
+		content := screen.SubImage(clip).(*ebiten.Image)
+		first := int(lb.ScrollY / lb.ItemHeight)
+		last := min(n, int((lb.ScrollY+view.H)/lb.ItemHeight)+1)
+		for i := first; i < last; i++ {
+			row := NewRect(view.X, view.Y+float32(i)*lb.ItemHeight-lb.ScrollY, view.W, lb.ItemHeight)
+			state := ItemState{
+				Selected: lb.selected[i],
+				Hovered:  i == lb.hovered,
+				Focused:  lb.IsActive && i == lb.cursor,
+				Disabled: !lb.Enabled,
+			}
+			switch {
+			case state.Selected:
+				draw.FillRect(content, row.X, row.Y, row.W, row.H, lb.color(lb.SelectedColor))
+			case state.Hovered:
+				draw.FillRect(content, row.X, row.Y, row.W, row.H, lb.color(lb.HoverColor))
+			}
+			lb.Source.DrawItem(content, i, row, state)
+			if state.Focused {
+				draw.StrokeRect(content, row.X, row.Y, row.W, row.H, 1, lb.color(lb.BorderColor))
+			}
+		}
+	}
+
+	// Draw the scrollbar when the items do not fit.
+	if track, thumb, ok := lb.scrollbarRects(); ok {
+		draw.FillRect(screen, track.X, track.Y, track.W, track.H, lb.color(paint.Fade(lb.ScrollbarColor, 0.25)))
+		draw.FillRect(screen, thumb.X, thumb.Y, thumb.W, thumb.H, lb.color(lb.ScrollbarColor))
+	}
+
+	border := float32(1)
+	if lb.IsActive {
+		border = 2
+	}
+	draw.StrokeRect(screen, lb.Bounds.X, lb.Bounds.Y, lb.Bounds.W, lb.Bounds.H, border, lb.color(lb.BorderColor))
+}
+
+// color fades c if the list box is disabled.
+func (lb *ListBox) color(c color.RGBA) color.RGBA {
+	if lb.Enabled {
+		return c
+	}
+	return paint.Fade(c, 0.5)
+}
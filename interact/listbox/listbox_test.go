@@ -0,0 +1,285 @@
+package listbox_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/listbox"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/testutil"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// rows is a source of n items that records which ones it was asked to draw.
+type rows struct {
+	n     int
+	drawn []int
+}
+
+func (r *rows) Len() int { return r.n }
+
+func (r *rows) DrawItem(screen *ebiten.Image, index int, bounds listbox.Rect, state listbox.ItemState) {
+	r.drawn = append(r.drawn, index)
+}
+
+// newList returns a list box of n items showing five 20px rows: item i is
+// centred at y=21+20*i while scrolled to the top.
+func newList(n int) (*listbox.ListBox, *rows) {
+	src := &rows{n: n}
+	lb := listbox.New(10, 10, 200, 102, 20, src)
+	keymap := input.PCKeymap()
+	lb.SetKeymap(&keymap)
+	return lb, src
+}
+
+// itemY returns the y coordinate of the middle of item i, scrolled to the top.
+func itemY(i int) int {
+	return 21 + 20*i
+}
+
+func TestClickSelects(t *testing.T) {
+	lb, _ := newList(10)
+	var changes [][]int
+	lb.OnSelectionChange = func(selected []int) { changes = append(changes, selected) }
+	h := testutil.NewHarness(lb)
+	defer h.Close()
+
+	h.Click(50, itemY(2))
+	if lb.Selected() != 2 || !lb.IsActive {
+		t.Fatalf("selected %d active %v after clicking item 2, want 2 and active", lb.Selected(), lb.IsActive)
+	}
+	h.Click(50, itemY(4))
+	if !slices.Equal(lb.SelectedIndices(), []int{4}) {
+		t.Fatalf("selected %v after clicking item 4, want only 4", lb.SelectedIndices())
+	}
+	// Clicking the selected item again changes nothing.
+	h.Advance(30)
+	h.Click(50, itemY(4))
+	if fmt.Sprint(changes) != "[[2] [4]]" {
+		t.Fatalf("OnSelectionChange got %v, want [[2] [4]]", changes)
+	}
+
+	// In single selection modifiers make no difference.
+	h.Input.PressKey(ebiten.KeyControl)
+	h.Click(50, itemY(1))
+	h.Input.ReleaseKey(ebiten.KeyControl)
+	if !slices.Equal(lb.SelectedIndices(), []int{1}) {
+		t.Fatalf("selected %v after control-clicking item 1, want only 1", lb.SelectedIndices())
+	}
+
+	h.Click(400, 400)
+	if lb.IsActive || lb.Selected() != 1 {
+		t.Fatalf("clicking outside: active %v selected %d, want inactive and 1 still selected", lb.IsActive, lb.Selected())
+	}
+}
+
+func TestMultiSelectClicks(t *testing.T) {
+	lb, _ := newList(10)
+	lb.SetMultiSelect(true)
+	h := testutil.NewHarness(lb)
+	defer h.Close()
+
+	h.Click(50, itemY(1))
+	h.Input.PressKey(ebiten.KeyControl)
+	h.Click(50, itemY(3))
+	h.Advance(30)
+	h.Click(50, itemY(4))
+	h.Advance(30)
+	h.Click(50, itemY(1))
+	h.Input.ReleaseKey(ebiten.KeyControl)
+	if !slices.Equal(lb.SelectedIndices(), []int{3, 4}) {
+		t.Fatalf("selected %v after control-clicking 3, 4 and 1, want [3 4]", lb.SelectedIndices())
+	}
+
+	// Shift selects from the last item clicked, replacing the rest.
+	h.Input.PressKey(ebiten.KeyShift)
+	h.Click(50, itemY(0))
+	h.Input.ReleaseKey(ebiten.KeyShift)
+	if !slices.Equal(lb.SelectedIndices(), []int{0, 1}) {
+		t.Fatalf("selected %v after shift-clicking 0 from 1, want [0 1]", lb.SelectedIndices())
+	}
+
+	lb.SetMultiSelect(false)
+	if len(lb.SelectedIndices()) != 1 {
+		t.Fatalf("selected %v after turning off multi-select, want one item", lb.SelectedIndices())
+	}
+}
+
+func TestKeyboardNavigation(t *testing.T) {
+	lb, _ := newList(100)
+	lb.SetMultiSelect(true)
+	h := testutil.NewHarness(lb)
+	defer h.Close()
+
+	h.Click(50, itemY(0))
+	h.PressKeys(ebiten.KeyDown)
+	h.PressKeys(ebiten.KeyDown)
+	if !slices.Equal(lb.SelectedIndices(), []int{2}) {
+		t.Fatalf("selected %v after Down twice, want [2]", lb.SelectedIndices())
+	}
+	h.PressKeys(ebiten.KeyShift, ebiten.KeyDown)
+	h.PressKeys(ebiten.KeyShift, ebiten.KeyDown)
+	if !slices.Equal(lb.SelectedIndices(), []int{2, 3, 4}) {
+		t.Fatalf("selected %v after Shift+Down twice, want [2 3 4]", lb.SelectedIndices())
+	}
+
+	// Page Down moves by the five rows shown and scrolls the item into view.
+	h.PressKeys(ebiten.KeyPageDown)
+	if lb.Current() != 9 || !slices.Equal(lb.SelectedIndices(), []int{9}) {
+		t.Fatalf("current %d selected %v after Page Down, want 9", lb.Current(), lb.SelectedIndices())
+	}
+	if lb.ScrollY != 100 {
+		t.Fatalf("ScrollY %v after Page Down to item 9, want 100 so its row ends the view", lb.ScrollY)
+	}
+
+	h.PressKeys(ebiten.KeyEnd)
+	if lb.Current() != 99 || lb.ScrollY != 1900 {
+		t.Fatalf("current %d ScrollY %v after End, want 99 and 1900", lb.Current(), lb.ScrollY)
+	}
+	h.PressKeys(ebiten.KeyHome)
+	if lb.Current() != 0 || lb.ScrollY != 0 {
+		t.Fatalf("current %d ScrollY %v after Home, want 0 and 0", lb.Current(), lb.ScrollY)
+	}
+
+	// Control moves without selecting; Space toggles.
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyDown)
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyDown)
+	h.PressKeys(ebiten.KeySpace)
+	if !slices.Equal(lb.SelectedIndices(), []int{0, 2}) {
+		t.Fatalf("selected %v after Control+Down twice and Space, want [0 2]", lb.SelectedIndices())
+	}
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyA)
+	if len(lb.SelectedIndices()) != 100 {
+		t.Fatalf("%d items selected after Control+A, want all 100", len(lb.SelectedIndices()))
+	}
+
+	h.PressKeys(ebiten.KeyEscape)
+	h.PressKeys(ebiten.KeyDown)
+	if lb.IsActive || lb.Current() != 2 {
+		t.Fatalf("active %v current %d after Escape and Down, want inactive and unmoved", lb.IsActive, lb.Current())
+	}
+}
+
+func TestHeldKeyRepeats(t *testing.T) {
+	lb, _ := newList(100)
+	h := testutil.NewHarness(lb)
+	defer h.Close()
+
+	lb.SetSelected(0)
+	lb.Activate()
+	// 0.5s delay then 30 repeats a second: the press, then 15 repeats in the
+	// 59 frames it stays held.
+	h.HoldKeys(60, ebiten.KeyDown)
+	if lb.Selected() != 16 {
+		t.Fatalf("selected %d after holding Down for a second, want 16", lb.Selected())
+	}
+}
+
+func TestActivate(t *testing.T) {
+	lb, _ := newList(10)
+	var activated []int
+	lb.OnActivate = func(index int) { activated = append(activated, index) }
+	h := testutil.NewHarness(lb)
+	defer h.Close()
+
+	h.Click(50, itemY(1))
+	h.Click(50, itemY(1))
+	if !slices.Equal(activated, []int{1}) {
+		t.Fatalf("activated %v after double-clicking item 1, want [1]", activated)
+	}
+	// A third quick click does not activate again.
+	h.Click(50, itemY(1))
+	// Slow clicks, and quick clicks on different items, do not activate.
+	h.Advance(30)
+	h.Click(50, itemY(1))
+	h.Click(50, itemY(2))
+	if len(activated) != 1 {
+		t.Fatalf("activated %v, want only the first double-click", activated)
+	}
+
+	h.PressKeys(ebiten.KeyEnter)
+	if !slices.Equal(activated, []int{1, 2}) {
+		t.Fatalf("activated %v after Enter on item 2, want [1 2]", activated)
+	}
+}
+
+func TestWheelScrolls(t *testing.T) {
+	lb, _ := newList(10)
+	h := testutil.NewHarness(lb)
+	defer h.Close()
+
+	h.MoveCursor(50, 50)
+	h.Input.Scroll(0, -1)
+	h.Frame()
+	if lb.ScrollY != 60 {
+		t.Fatalf("ScrollY %v after one wheel step down, want three rows (60)", lb.ScrollY)
+	}
+	h.Input.Scroll(0, -1)
+	h.Frame()
+	if lb.ScrollY != 100 {
+		t.Fatalf("ScrollY %v after scrolling past the end, want the limit of 100", lb.ScrollY)
+	}
+	// With the list scrolled, clicks land on the item under the cursor.
+	h.Click(50, itemY(0))
+	if lb.Selected() != 5 {
+		t.Fatalf("selected %d after clicking the top row scrolled by 100px, want 5", lb.Selected())
+	}
+
+	h.MoveCursor(400, 400)
+	h.Input.Scroll(0, 1)
+	h.Frame()
+	if lb.ScrollY != 100 {
+		t.Fatalf("ScrollY %v after scrolling outside the list box, want it unchanged", lb.ScrollY)
+	}
+}
+
+func TestDragScrollbar(t *testing.T) {
+	lb, _ := newList(10000)
+	h := testutil.NewHarness(lb)
+	defer h.Close()
+
+	// The track runs from y=12 to y=110 with a 16px thumb, leaving 82px of travel.
+	h.Press(204, 15)
+	if lb.Selected() != -1 {
+		t.Fatal("pressing the scrollbar selected an item")
+	}
+	h.MoveCursor(204, 15+41)
+	h.Release(204, 15+41)
+	if lb.ScrollY != 199900/2 {
+		t.Fatalf("ScrollY %v after dragging the thumb halfway, want %v", lb.ScrollY, 199900/2)
+	}
+
+	// Clicking the track centres the thumb on the pointer.
+	h.Click(204, 110)
+	if lb.ScrollY != 199900 {
+		t.Fatalf("ScrollY %v after clicking the bottom of the track, want the end", lb.ScrollY)
+	}
+}
+
+func TestDrawsOnlyVisibleRows(t *testing.T) {
+	lb, src := newList(10000)
+	lb.ScrollBy(1000*20 + 10)
+	screen := ebiten.NewImage(300, 300)
+
+	lb.Draw(screen)
+	if !slices.Equal(src.drawn, []int{1000, 1001, 1002, 1003, 1004, 1005}) {
+		t.Fatalf("drew items %v, want only the six rows partly on screen", src.drawn)
+	}
+}
+
+func TestSourceShrinks(t *testing.T) {
+	lb, src := newList(10)
+	lb.SetMultiSelect(true)
+	h := testutil.NewHarness(lb)
+	defer h.Close()
+
+	lb.SetItemSelected(2, true)
+	lb.SetItemSelected(8, true)
+	lb.EnsureVisible(9)
+	src.n = 5
+	h.Frame()
+	if !slices.Equal(lb.SelectedIndices(), []int{2}) || lb.ScrollY != 0 {
+		t.Fatalf("selected %v ScrollY %v after the source shrank to 5, want [2] and 0", lb.SelectedIndices(), lb.ScrollY)
+	}
+}
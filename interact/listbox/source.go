@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: MIT
+package listbox
+
+import (
+	"image/color"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/colorscheme"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/paint"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// DataSource supplies the items of a ListBox. The list box never holds the
+// items itself: it asks for the count every frame and draws only the rows
+// that are on screen, so a source can be backed by a slice, a save directory
+// or a server browser's results without a widget per row.
+type DataSource interface {
+	// Len returns the number of items.
+	Len() int
+	// DrawItem draws the item at index inside bounds. The list box has
+	// already filled the row's background for the state, and screen is
+	// clipped to the visible part of the list.
+	DrawItem(screen *ebiten.Image, index int, bounds Rect, state ItemState)
+}
+
+// ItemState is how a row is shown.
+type ItemState struct {
+	Selected bool
+	Hovered  bool
+	Focused  bool // The row the keyboard is on, while the list box is active
+	Disabled bool // The list box is disabled
+}
+
+// Strings is a DataSource drawing each item as a line of text.
+type Strings struct {
+	Items     []string
+	TextColor color.RGBA
+	Padding   float32
+	FontSize  int32
+	FontFace  font.Face
+}
+
+// NewStrings creates a source of text items drawn in DefaultFont.
+func NewStrings(items ...string) *Strings {
+	return &Strings{
+		Items:     items,
+		TextColor: color.RGBA{R: 0, G: 0, B: 0, A: 255}, // Black
+		Padding:   6.0,
+		FontSize:  20,
+		FontFace:  DefaultFont,
+	}
+}
+
+func (s *Strings) Len() int {
+	return len(s.Items)
+}
+
+// DrawItem draws the item's text, vertically centred in the row.
+func (s *Strings) DrawItem(screen *ebiten.Image, index int, bounds Rect, state ItemState) {
+	if s.FontFace == nil {
+		return
+	}
+	col := s.TextColor
+	if state.Disabled {
+		col = paint.Fade(col, 0.5)
+	}
+	textY := bounds.Y + (bounds.H-float32(s.FontSize))/2
+	text.Draw(screen, s.Items[index], s.FontFace, int(bounds.X+s.Padding), int(textY)+int(s.FontSize), col)
+}
+
+// SetColorScheme draws the text in the scheme's text color.
+func (s *Strings) SetColorScheme(scheme colorscheme.ColorScheme) {
+	s.TextColor = scheme.Text
+}
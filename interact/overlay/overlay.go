@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MIT
+
+// Package overlay lets widgets draw on top of everything else and keep the
+// mouse away from the widgets they cover, as open dropdown lists must.
+//
+// A widget with a popup defers drawing it with Defer during its Draw, and
+// the game draws all deferred popups once everything else is drawn by calling
+// Draw, once, at the end of its own Draw. Each owner has at most one drawing
+// queued, so a game that never calls Draw does not pile up popups. While
+// the popup is open its owner marks the area with Cover every frame, and other
+// widgets ignore the mouse there. A cover that is not renewed, say because its
+// widget stopped being updated while open, lapses after two calls to Draw.
+package overlay
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+type deferral struct {
+	owner any
+	f     func(screen *ebiten.Image)
+}
+
+type cover struct {
+	owner      any
+	x, y, w, h float32
+	frame      int // The value of frame when the cover was last renewed
+}
+
+var (
+	deferred []deferral
+	running  []deferral // The queue Draw is running, kept to reuse its memory
+	covers   []cover
+	frame    int // Counts calls to Draw
+)
+
+// Defer queues f to draw on top of everything drawn before the next call to
+// Draw, replacing any drawing owner queued before.
+func Defer(owner any, f func(screen *ebiten.Image)) {
+	d := deferral{owner, f}
+	for i := range deferred {
+		if deferred[i].owner == owner {
+			deferred[i] = d
+			return
+		}
+	}
+	deferred = append(deferred, d)
+}
+
+// Draw runs the deferred drawing functions in the order they were queued,
+// then empties the queue.
+func Draw(screen *ebiten.Image) {
+	// Popups may defer more drawing of their own, which runs after them.
+	for len(deferred) > 0 {
+		running, deferred = deferred, running[:0]
+		for _, d := range running {
+			d.f(screen)
+		}
+		clear(running)
+	}
+
+	frame++
+	kept := covers[:0]
+	for _, c := range covers {
+		if frame-c.frame < 2 {
+			kept = append(kept, c)
+		}
+	}
+	clear(covers[len(kept):])
+	covers = kept
+}
+
+// Reset drops all covers and deferred drawing, as when switching to a screen
+// with different widgets.
+func Reset() {
+	clear(deferred)
+	deferred = deferred[:0]
+	clear(covers)
+	covers = covers[:0]
+}
+
+// Cover marks a rectangle as covered by owner, replacing any rectangle owner
+// covered before, until owner calls Uncover or stops renewing it.
+func Cover(owner any, x, y, w, h float32) {
+	c := cover{owner, x, y, w, h, frame}
+	for i := range covers {
+		if covers[i].owner == owner {
+			covers[i] = c
+			return
+		}
+	}
+	covers = append(covers, c)
+}
+
+// Uncover removes the rectangle owner covers and the drawing it deferred, if
+// any, as when its popup closes.
+func Uncover(owner any) {
+	for i := range covers {
+		if covers[i].owner == owner {
+			covers = append(covers[:i], covers[i+1:]...)
+			break
+		}
+	}
+	for i := range deferred {
+		if deferred[i].owner == owner {
+			deferred = append(deferred[:i], deferred[i+1:]...)
+			break
+		}
+	}
+}
+
+// Blocks reports whether a point is covered by an owner other than widget,
+// in which case widget should act as if the mouse were elsewhere.
+func Blocks(widget any, x, y float32) bool {
+	for _, c := range covers {
+		if c.owner != widget && x >= c.x && x <= c.x+c.w && y >= c.y && y <= c.y+c.h {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package overlay_test
+
+import (
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/overlay"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestDrawRunsDeferredInOrderOnce(t *testing.T) {
+	var order []int
+	first, second, nested := new(int), new(int), new(int)
+	overlay.Defer(first, func(*ebiten.Image) {
+		order = append(order, 1)
+		overlay.Defer(nested, func(*ebiten.Image) { order = append(order, 3) })
+	})
+	overlay.Defer(second, func(*ebiten.Image) { order = append(order, 2) })
+	overlay.Draw(nil)
+	overlay.Draw(nil)
+	if len(order) != 3 || order[0] != 1 || order[1] != 2 || order[2] != 3 {
+		t.Fatalf("ran %v, want [1 2 3]", order)
+	}
+}
+
+func TestDeferKeepsOneDrawingPerOwner(t *testing.T) {
+	popup, closed := new(int), new(int)
+	runs := 0
+	// Frames drawn without overlay.Draw must not pile up drawing.
+	for range 100 {
+		overlay.Defer(popup, func(*ebiten.Image) { runs++ })
+	}
+	overlay.Defer(closed, func(*ebiten.Image) { t.Fatal("drawing deferred by an uncovered owner ran") })
+	overlay.Uncover(closed)
+	overlay.Draw(nil)
+	if runs != 1 {
+		t.Fatalf("deferred drawing ran %d times, want once", runs)
+	}
+}
+
+func TestCoverBlocksOthers(t *testing.T) {
+	popup, other := new(int), new(int)
+	overlay.Cover(popup, 10, 10, 100, 100)
+	defer overlay.Uncover(popup)
+
+	if !overlay.Blocks(other, 50, 50) {
+		t.Error("covered point not blocked for another widget")
+	}
+	if overlay.Blocks(popup, 50, 50) {
+		t.Error("owner blocked by its own cover")
+	}
+	if overlay.Blocks(other, 200, 50) {
+		t.Error("point outside the cover blocked")
+	}
+
+	overlay.Cover(popup, 300, 300, 10, 10)
+	if overlay.Blocks(other, 50, 50) {
+		t.Error("moving the cover left the old area blocked")
+	}
+	overlay.Uncover(popup)
+	if overlay.Blocks(other, 305, 305) {
+		t.Error("uncovered point still blocked")
+	}
+}
+
+func TestUnrenewedCoverLapses(t *testing.T) {
+	popup, other := new(int), new(int)
+	overlay.Cover(popup, 0, 0, 10, 10)
+	overlay.Draw(nil)
+	if !overlay.Blocks(other, 5, 5) {
+		t.Fatal("cover lapsed after one frame")
+	}
+	overlay.Draw(nil)
+	if overlay.Blocks(other, 5, 5) {
+		t.Fatal("cover still there two frames after it was last renewed")
+	}
+}
+
+func TestReset(t *testing.T) {
+	overlay.Cover(new(int), 0, 0, 10, 10)
+	overlay.Defer(new(int), func(*ebiten.Image) { t.Fatal("deferred drawing survived Reset") })
+	overlay.Reset()
+	overlay.Draw(nil)
+	if overlay.Blocks(new(int), 5, 5) {
+		t.Fatal("cover survived Reset")
+	}
+}
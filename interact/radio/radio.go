@@ -0,0 +1,375 @@
+// SPDX-License-Identifier: MIT
+
+// Package radio provides groups of radio buttons, of which exactly one at a
+// time can be selected.
+package radio
+
+import (
+	"image/color"
+	"reflect"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/clock"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/colorscheme"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/draw"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/paint"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/overlay"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// Rect defines a rectangle with float32 coordinates.
+type Rect struct {
+	X, Y, W, H float32
+}
+
+func NewRect(x, y, w, h float32) Rect {
+	return Rect{x, y, w, h}
+}
+
+func pointInRect(x, y float32, r Rect) bool {
+	return x >= r.X && x <= r.X+r.W && y >= r.Y && y <= r.Y+r.H
+}
+
+// DefaultFont is a package-level font face used for drawing text.
+// Set this to a valid font.Face during initialization.
+var DefaultFont font.Face
+
+// Option is one choice in a RadioGroup.
+type Option struct {
+	Label    string
+	Value    any  // Whatever the option stands for, such as a difficulty level
+	Disabled bool // Shown faded and skipped by clicks and arrow keys
+}
+
+// RadioGroup is a list of options drawn as radio buttons. Bounds is divided
+// evenly between them, in rows or, if Horizontal is set, in columns. Clicking an option selects it. Once
+// clicked the group is active and the arrow keys move the selection, skipping
+// disabled options and wrapping around.
+type RadioGroup struct {
+	Bounds          Rect
+	Options         []Option
+	Horizontal      bool
+	BackgroundColor color.RGBA // Inside of the circles
+	HoverColor      color.RGBA
+	PressedColor    color.RGBA
+	BorderColor     color.RGBA
+	TextColor       color.RGBA
+	DotColor        color.RGBA // The dot in the selected circle
+	FontSize        int32
+	FontFace        font.Face
+	Padding         float32
+	Enabled         bool
+	Invisible       bool
+	IsActive        bool             // Arrow keys move the selection
+	Input           input.Input      // Input source; nil uses input.Default()
+	KeyRepeat       *input.KeyRepeat // Timing of held arrow keys; nil uses input.DefaultKeyRepeat()
+	Clock           clock.Clock      // Frame timing for key repeat; nil uses clock.Default()
+
+	// OnChange is called from Update when a click or arrow key selects a
+	// different option.
+	OnChange func(index int, option Option)
+
+	selected int
+	hovered  int
+	pressed  int
+	repeater input.Repeater
+}
+
+// New creates a group at x, y with one row of the given height per option and
+// nothing selected.
+func New(x, y, width, rowHeight float32, options ...Option) *RadioGroup {
+	return &RadioGroup{
+		Bounds:          NewRect(x, y, width, rowHeight*float32(len(options))),
+		Options:         options,
+		BackgroundColor: color.RGBA{R: 255, G: 255, B: 255, A: 255}, // White
+		HoverColor:      color.RGBA{R: 230, G: 230, B: 230, A: 255},
+		PressedColor:    color.RGBA{R: 200, G: 200, B: 200, A: 255},
+		BorderColor:     color.RGBA{R: 0, G: 0, B: 0, A: 255}, // Black
+		TextColor:       color.RGBA{R: 0, G: 0, B: 0, A: 255}, // Black
+		DotColor:        color.RGBA{R: 0, G: 0, B: 0, A: 255}, // Black
+		FontSize:        20,
+		FontFace:        DefaultFont,
+		Padding:         5.0,
+		Enabled:         true,
+		selected:        -1,
+		hovered:         -1,
+		pressed:         -1,
+	}
+}
+
+// NewHorizontal creates a group at x, y with the options side by side in
+// columns of equal width and nothing selected.
+func NewHorizontal(x, y, width, height float32, options ...Option) *RadioGroup {
+	g := New(x, y, width, height, options...)
+	g.Bounds.H = height
+	g.Horizontal = true
+	return g
+}
+
+// Labels returns options whose values are their labels.
+func Labels(labels ...string) []Option {
+	options := make([]Option, len(labels))
+	for i, label := range labels {
+		options[i] = Option{Label: label, Value: label}
+	}
+	return options
+}
+
+// Selected returns the index of the selected option, or -1 if none is.
+func (g *RadioGroup) Selected() int {
+	if g.selected >= len(g.Options) {
+		return -1
+	}
+	return g.selected
+}
+
+// SelectedOption returns the selected option, if any.
+func (g *RadioGroup) SelectedOption() (Option, bool) {
+	i := g.Selected()
+	if i < 0 {
+		return Option{}, false
+	}
+	return g.Options[i], true
+}
+
+// SetSelected selects the option at index without calling OnChange. An index
+// out of range clears the selection.
+func (g *RadioGroup) SetSelected(index int) {
+	if index < 0 || index >= len(g.Options) {
+		index = -1
+	}
+	g.selected = index
+}
+
+// SelectValue selects the first option with the given value without calling
+// OnChange, and reports whether there was one. Values are compared with ==;
+// values that cannot be, such as slices, maps and funcs, never match.
+func (g *RadioGroup) SelectValue(value any) bool {
+	for i, o := range g.Options {
+		if equal(o.Value, value) {
+			g.selected = i
+			return true
+		}
+	}
+	return false
+}
+
+func (g *RadioGroup) SetColors(background, hover, pressed, border, text, dot color.RGBA) {
+	g.BackgroundColor = background
+	g.HoverColor = hover
+	g.PressedColor = pressed
+	g.BorderColor = border
+	g.TextColor = text
+	g.DotColor = dot
+}
+
+// SetColorScheme styles the group like a Button with the same scheme. The
+// dot takes the border color.
+func (g *RadioGroup) SetColorScheme(scheme colorscheme.ColorScheme) {
+	g.SetColors(scheme.Background, scheme.Hover, scheme.Pressed, scheme.Border, scheme.Text, scheme.Border)
+}
+
+func (g *RadioGroup) SetFontSize(size int32) {
+	g.FontSize = size
+}
+
+func (g *RadioGroup) SetFont(face font.Face) {
+	g.FontFace = face
+}
+
+func (g *RadioGroup) SetHorizontal(horizontal bool) {
+	g.Horizontal = horizontal
+}
+
+func (g *RadioGroup) SetEnabled(enabled bool) {
+	g.Enabled = enabled
+}
+
+func (g *RadioGroup) SetInvisible(invisible bool) {
+	g.Invisible = invisible
+}
+
+// SetInput sets the input source the group reads from. Passing nil uses input.Default().
+func (g *RadioGroup) SetInput(in input.Input) {
+	g.Input = in
+}
+
+// SetKeyRepeat sets the timing of held arrow keys. Passing nil uses input.DefaultKeyRepeat().
+func (g *RadioGroup) SetKeyRepeat(repeat *input.KeyRepeat) {
+	g.KeyRepeat = repeat
+}
+
+// SetClock sets the clock key repeat is timed by. Passing nil uses clock.Default().
+func (g *RadioGroup) SetClock(c clock.Clock) {
+	g.Clock = c
+}
+
+// Activate lets the arrow keys move the selection.
+func (g *RadioGroup) Activate() {
+	g.IsActive = true
+}
+
+// Deactivate stops the arrow keys moving the selection.
+func (g *RadioGroup) Deactivate() {
+	g.IsActive = false
+}
+
+// itemRect returns the bounds of the option at index.
+func (g *RadioGroup) itemRect(index int) Rect {
+	n := float32(max(len(g.Options), 1))
+	if g.Horizontal {
+		w := g.Bounds.W / n
+		return NewRect(g.Bounds.X+w*float32(index), g.Bounds.Y, w, g.Bounds.H)
+	}
+	h := g.Bounds.H / n
+	return NewRect(g.Bounds.X, g.Bounds.Y+h*float32(index), g.Bounds.W, h)
+}
+
+// itemAt returns the index of the option at x, y, or -1 if there is none.
+func (g *RadioGroup) itemAt(x, y float32) int {
+	for i := range g.Options {
+		if pointInRect(x, y, g.itemRect(i)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Update should be called every frame.
+func (g *RadioGroup) Update() {
+	if !g.Enabled {
+		g.hovered, g.pressed = -1, -1
+		g.IsActive = false
+		return
+	}
+
+	in := input.Or(g.Input)
+	mx, my := in.CursorPosition()
+	x, y := float32(mx), float32(my)
+	blocked := overlay.Blocks(g, x, y)
+	g.hovered = g.itemAt(x, y)
+	if blocked || (g.hovered >= 0 && g.Options[g.hovered].Disabled) {
+		g.hovered = -1
+	}
+
+	if in.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		g.IsActive = pointInRect(x, y, g.Bounds) && !blocked
+		g.pressed = g.hovered
+	}
+	if in.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		if g.pressed >= 0 && g.pressed == g.hovered {
+			g.choose(g.pressed)
+		}
+		g.pressed = -1
+	}
+
+	g.repeater.Update(in, input.KeyRepeatOr(g.KeyRepeat), clock.Or(g.Clock).Delta())
+	if !g.IsActive {
+		return
+	}
+	// As in other toolkits, both pairs of arrows work whichever way the
+	// options are laid out.
+	for range g.repeater.Presses(in, ebiten.KeyUp) + g.repeater.Presses(in, ebiten.KeyLeft) {
+		g.step(-1)
+	}
+	for range g.repeater.Presses(in, ebiten.KeyDown) + g.repeater.Presses(in, ebiten.KeyRight) {
+		g.step(1)
+	}
+	if in.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.IsActive = false
+	}
+}
+
+// step moves the selection by dir options, skipping disabled ones and
+// wrapping around. With nothing selected it starts from the first or last.
+func (g *RadioGroup) step(dir int) {
+	n := len(g.Options)
+	i := g.Selected()
+	if i < 0 && dir > 0 {
+		i = n - 1
+	} else if i < 0 {
+		i = 0
+	}
+	for range n {
+		i = (i + dir + n) % n
+		if !g.Options[i].Disabled {
+			g.choose(i)
+			return
+		}
+	}
+}
+
+// choose selects the option at index, calling OnChange if it was not already selected.
+func (g *RadioGroup) choose(index int) {
+	if index == g.selected {
+		return
+	}
+	g.selected = index
+	if g.OnChange != nil {
+		g.OnChange(index, g.Options[index])
+	}
+}
+
+// Draw draws the options, and a focus outline around the selected one while
+// the group is active.
+func (g *RadioGroup) Draw(screen *ebiten.Image) {
+	if g.Invisible {
+		return
+	}
+	for i, o := range g.Options {
+		r := g.itemRect(i)
+		disabled := !g.Enabled || o.Disabled
+
+		fill := g.BackgroundColor
+		switch {
+		case disabled:
+		case i == g.pressed && i == g.hovered:
+			fill = g.PressedColor
+		case i == g.hovered:
+			fill = g.HoverColor
+		}
+
+		// The circle is as tall as the row less the padding, at the left.
+		size := max(min(r.H-2*g.Padding, float32(g.FontSize)), 2)
+		radius := size / 2
+		cx, cy := r.X+g.Padding+radius, r.Y+r.H/2
+		draw.FillCircle(screen, cx, cy, radius, faded(fill, disabled))
+		draw.StrokeCircle(screen, cx, cy, radius-1, 2, faded(g.BorderColor, disabled))
+		if i == g.selected {
+			draw.FillCircle(screen, cx, cy, radius*0.5, faded(g.DotColor, disabled))
+		}
+
+		if g.IsActive && i == g.selected {
+			draw.StrokeRect(screen, r.X, r.Y, r.W, r.H, 1, g.BorderColor)
+		}
+
+		if g.FontFace == nil {
+			continue
+		}
+		textX := cx + radius + g.Padding
+		textY := r.Y + (r.H-float32(g.FontSize))/2
+		text.Draw(screen, o.Label, g.FontFace, int(textX), int(textY)+int(g.FontSize), faded(g.TextColor, disabled))
+	}
+}
+
+// equal reports whether a == b, treating values that would make == panic as
+// unequal.
+func equal(a, b any) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.ValueOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// faded halves a premultiplied color's opacity if disabled is set.
+func faded(c color.RGBA, disabled bool) color.RGBA {
+	if !disabled {
+		return c
+	}
+	return paint.Fade(c, 0.5)
+}
@@ -0,0 +1,135 @@
+package radio_test
+
+import (
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/radio"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/testutil"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// newDifficulty returns a group of three 30px rows at (10, 10).
+func newDifficulty() *radio.RadioGroup {
+	return radio.New(10, 10, 200, 30, radio.Labels("Easy", "Normal", "Hard")...)
+}
+
+func TestClickSelectsExclusively(t *testing.T) {
+	g := newDifficulty()
+	var changes []int
+	g.OnChange = func(index int, option radio.Option) {
+		if option.Value != g.Options[index].Value {
+			t.Errorf("OnChange given option %v for index %d", option.Value, index)
+		}
+		changes = append(changes, index)
+	}
+	h := testutil.NewHarness(g)
+	defer h.Close()
+
+	if g.Selected() != -1 {
+		t.Fatalf("new group has option %d selected", g.Selected())
+	}
+	h.Click(50, 55) // Normal
+	h.Click(50, 85) // Hard
+	h.Click(50, 85) // Hard again: no change
+	if g.Selected() != 2 {
+		t.Fatalf("selected %d, want 2", g.Selected())
+	}
+	if len(changes) != 2 || changes[0] != 1 || changes[1] != 2 {
+		t.Fatalf("OnChange called with %v, want [1 2]", changes)
+	}
+	if o, ok := g.SelectedOption(); !ok || o.Value != "Hard" {
+		t.Fatalf("SelectedOption = %v, %v", o, ok)
+	}
+}
+
+func TestReleaseElsewhereDoesNotSelect(t *testing.T) {
+	g := newDifficulty()
+	h := testutil.NewHarness(g)
+	defer h.Close()
+
+	h.Press(50, 25)
+	h.Release(50, 85)
+	if g.Selected() != -1 {
+		t.Fatalf("selected %d after dragging off the pressed option", g.Selected())
+	}
+}
+
+func TestArrowKeysMoveAndWrap(t *testing.T) {
+	g := newDifficulty()
+	g.Options[1].Disabled = true
+	h := testutil.NewHarness(g)
+	defer h.Close()
+
+	h.PressKeys(ebiten.KeyDown)
+	if g.Selected() != -1 {
+		t.Fatal("arrow keys moved the selection of an inactive group")
+	}
+
+	h.Click(50, 25) // Easy, and the group becomes active
+	h.PressKeys(ebiten.KeyDown)
+	if g.Selected() != 2 {
+		t.Fatalf("Down selected %d, want 2 skipping the disabled option", g.Selected())
+	}
+	h.PressKeys(ebiten.KeyRight)
+	if g.Selected() != 0 {
+		t.Fatalf("Right from the last option selected %d, want to wrap to 0", g.Selected())
+	}
+	h.PressKeys(ebiten.KeyUp)
+	if g.Selected() != 2 {
+		t.Fatalf("Up from the first option selected %d, want to wrap to 2", g.Selected())
+	}
+
+	h.Click(300, 300)
+	h.PressKeys(ebiten.KeyUp)
+	if g.Selected() != 2 || g.IsActive {
+		t.Fatal("group still active after clicking elsewhere")
+	}
+}
+
+func TestDisabledOptionIgnoresClicks(t *testing.T) {
+	g := newDifficulty()
+	g.Options[0].Disabled = true
+	h := testutil.NewHarness(g)
+	defer h.Close()
+
+	h.Click(50, 25)
+	if g.Selected() != -1 {
+		t.Fatal("clicking a disabled option selected it")
+	}
+}
+
+func TestSetSelected(t *testing.T) {
+	g := newDifficulty()
+	g.OnChange = func(int, radio.Option) { t.Fatal("OnChange called by a setter") }
+	g.SetSelected(1)
+	if g.Selected() != 1 {
+		t.Fatalf("selected %d, want 1", g.Selected())
+	}
+	g.SetSelected(7)
+	if g.Selected() != -1 {
+		t.Fatalf("out of range index left %d selected", g.Selected())
+	}
+	if !g.SelectValue("Hard") || g.Selected() != 2 {
+		t.Fatal("SelectValue did not select Hard")
+	}
+	if g.SelectValue("Nightmare") {
+		t.Fatal("SelectValue found a missing value")
+	}
+
+	// Values that == cannot compare never match, rather than panicking.
+	g = radio.New(0, 0, 100, 20, radio.Option{Label: "Easy", Value: []int{1}}, radio.Option{Label: "Hard", Value: 3})
+	if g.SelectValue([]int{1}) || !g.SelectValue(3) || g.Selected() != 1 {
+		t.Fatalf("selecting among slice and int values selected %d, want only the int to match", g.Selected())
+	}
+}
+
+func TestHorizontalLayout(t *testing.T) {
+	g := radio.NewHorizontal(0, 0, 300, 30, radio.Labels("Low", "Medium", "High")...)
+	h := testutil.NewHarness(g)
+	defer h.Close()
+
+	h.Click(250, 15)
+	if g.Selected() != 2 {
+		t.Fatalf("selected %d, want the third column", g.Selected())
+	}
+}
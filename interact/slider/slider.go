@@ -0,0 +1,512 @@
+// SPDX-License-Identifier: MIT
+
+// Package slider provides sliders for picking a number from a range, such as
+// a volume or a mouse sensitivity.
+package slider
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/clock"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/colorscheme"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/draw"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/paint"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/overlay"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// Rect defines a rectangle with float32 coordinates.
+type Rect struct {
+	X, Y, W, H float32
+}
+
+func NewRect(x, y, w, h float32) Rect {
+	return Rect{x, y, w, h}
+}
+
+func pointInRect(x, y float32, r Rect) bool {
+	return x >= r.X && x <= r.X+r.W && y >= r.Y && y <= r.Y+r.H
+}
+
+// DefaultFont is a package-level font face used for drawing text.
+// Set this to a valid font.Face during initialization.
+var DefaultFont font.Face
+
+// maxTicks caps how many tick marks are drawn, however small the interval.
+const maxTicks = 200
+
+// Slider picks a value between Min and Max by dragging a thumb along a track.
+// Horizontal sliders increase to the right and vertical ones upwards.
+//
+// Clicking the track jumps the thumb there and starts dragging, or with
+// PageOnClick set moves the value a page towards the click. After a click the
+// slider is active: the arrow keys move it a step, Page Up and Page Down a
+// page, and Home and End to the ends. The mouse wheel moves it a step while
+// the cursor is over it.
+type Slider struct {
+	Bounds          Rect
+	Value           float64
+	Min, Max        float64
+	Step            float64 // Values snap to multiples of Step from Min; zero is continuous
+	PageStep        float64 // Page Up, Page Down and paging clicks; zero is a tenth of the range
+	Vertical        bool
+	PageOnClick     bool // Clicking the track pages instead of jumping
+	ShowTicks       bool
+	TickInterval    float64 // Distance between tick marks; zero uses Step
+	ShowValue       bool    // Draw the value after the slider
+	Format          func(float64) string
+	TrackColor      color.RGBA
+	FillColor       color.RGBA // The track between Min and the thumb
+	ThumbColor      color.RGBA
+	ThumbHoverColor color.RGBA
+	BorderColor     color.RGBA
+	TextColor       color.RGBA
+	ThumbSize       float32 // Diameter of the thumb; zero fits it to the bounds
+	TrackThickness  float32
+	FontSize        int32
+	FontFace        font.Face
+	Enabled         bool
+	Invisible       bool
+	IsActive        bool             // Keys move the slider
+	IsDragging      bool             // The thumb is held by the mouse
+	Input           input.Input      // Input source; nil uses input.Default()
+	KeyRepeat       *input.KeyRepeat // Timing of held keys; nil uses input.DefaultKeyRepeat()
+	Clock           clock.Clock      // Frame timing for key repeat; nil uses clock.Default()
+
+	// OnChange is called from Update whenever the user changes the value,
+	// including every frame it changes during a drag.
+	OnChange func(value float64)
+	// OnChangeEnd is called from Update once the user has finished changing
+	// the value: when a drag that moved the thumb ends, or after each key
+	// press, click or wheel movement that changed it. Expensive reactions,
+	// such as saving settings, belong here.
+	OnChangeEnd func(value float64)
+
+	hovered    bool
+	grabOffset float32
+	dragStart  float64
+	wheel      float64 // Wheel movement not yet turned into steps
+	repeater   input.Repeater
+}
+
+// New creates a horizontal slider from min to max, starting at min.
+func New(x, y, width, height float32, min, max float64) *Slider {
+	return &Slider{
+		Bounds:          NewRect(x, y, width, height),
+		Value:           min,
+		Min:             min,
+		Max:             max,
+		TrackColor:      color.RGBA{R: 200, G: 200, B: 200, A: 255},
+		FillColor:       color.RGBA{R: 0, G: 120, B: 215, A: 255}, // Blue
+		ThumbColor:      color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		ThumbHoverColor: color.RGBA{R: 230, G: 230, B: 230, A: 255},
+		BorderColor:     color.RGBA{R: 0, G: 0, B: 0, A: 255}, // Black
+		TextColor:       color.RGBA{R: 0, G: 0, B: 0, A: 255}, // Black
+		TrackThickness:  6.0,
+		FontSize:        20,
+		FontFace:        DefaultFont,
+		Enabled:         true,
+	}
+}
+
+// NewVertical creates a vertical slider from min at the bottom to max at the
+// top, starting at min.
+func NewVertical(x, y, width, height float32, min, max float64) *Slider {
+	s := New(x, y, width, height, min, max)
+	s.Vertical = true
+	return s
+}
+
+// SetValue moves the slider to value, snapped to the step and clamped to the
+// range, without calling OnChange.
+func (s *Slider) SetValue(value float64) {
+	s.Value = s.snap(value)
+}
+
+// SetRange sets the minimum, maximum and step, and moves the value into the range.
+func (s *Slider) SetRange(min, max, step float64) {
+	s.Min, s.Max, s.Step = min, max, step
+	s.Value = s.snap(s.Value)
+}
+
+func (s *Slider) SetPageStep(page float64) {
+	s.PageStep = page
+}
+
+func (s *Slider) SetPageOnClick(page bool) {
+	s.PageOnClick = page
+}
+
+// SetTicks shows tick marks every interval, or every step if interval is zero.
+func (s *Slider) SetTicks(show bool, interval float64) {
+	s.ShowTicks = show
+	s.TickInterval = interval
+}
+
+// SetShowValue sets whether the value is drawn after the slider, formatted by
+// format or, if that is nil, as a plain number.
+func (s *Slider) SetShowValue(show bool, format func(float64) string) {
+	s.ShowValue = show
+	s.Format = format
+}
+
+func (s *Slider) SetColors(track, fill, thumb, thumbHover, border, text color.RGBA) {
+	s.TrackColor = track
+	s.FillColor = fill
+	s.ThumbColor = thumb
+	s.ThumbHoverColor = thumbHover
+	s.BorderColor = border
+	s.TextColor = text
+}
+
+// SetColorScheme styles the slider to match buttons with the same scheme: the
+// thumb is drawn like a button and the filled track in the border color.
+func (s *Slider) SetColorScheme(scheme colorscheme.ColorScheme) {
+	s.SetColors(scheme.Pressed, scheme.Border, scheme.Background, scheme.Hover, scheme.Border, scheme.Text)
+}
+
+func (s *Slider) SetFontSize(size int32) {
+	s.FontSize = size
+}
+
+func (s *Slider) SetFont(face font.Face) {
+	s.FontFace = face
+}
+
+func (s *Slider) SetEnabled(enabled bool) {
+	s.Enabled = enabled
+}
+
+func (s *Slider) SetInvisible(invisible bool) {
+	s.Invisible = invisible
+}
+
+// SetInput sets the input source the slider reads from. Passing nil uses input.Default().
+func (s *Slider) SetInput(in input.Input) {
+	s.Input = in
+}
+
+// SetKeyRepeat sets the timing of held keys. Passing nil uses input.DefaultKeyRepeat().
+func (s *Slider) SetKeyRepeat(repeat *input.KeyRepeat) {
+	s.KeyRepeat = repeat
+}
+
+// SetClock sets the clock key repeat is timed by. Passing nil uses clock.Default().
+func (s *Slider) SetClock(c clock.Clock) {
+	s.Clock = c
+}
+
+// Activate lets the keys move the slider.
+func (s *Slider) Activate() {
+	s.IsActive = true
+}
+
+// Deactivate stops the keys moving the slider.
+func (s *Slider) Deactivate() {
+	s.IsActive = false
+}
+
+// snap rounds value to the nearest step and clamps it to the range.
+func (s *Slider) snap(value float64) float64 {
+	lo, hi := min(s.Min, s.Max), max(s.Min, s.Max)
+	if s.Step > 0 {
+		value = s.Min + math.Round((value-s.Min)/s.Step)*s.Step
+		// Steps such as 0.1 accumulate error: 0.1*3 is 0.30000000000000004.
+		value = math.Round(value*1e9) / 1e9
+	}
+	return max(lo, min(value, hi))
+}
+
+// keyStep returns how far an arrow key or wheel notch moves the value.
+func (s *Slider) keyStep() float64 {
+	if s.Step > 0 {
+		return s.Step
+	}
+	return (s.Max - s.Min) / 100
+}
+
+// wheelSteps returns how many steps a wheel movement of dy moves the value.
+// Trackpads and smooth wheels move a fraction of a notch at a time, which a
+// stepped slider collects until it adds up to a whole step.
+func (s *Slider) wheelSteps(dy float64) float64 {
+	if s.Step <= 0 {
+		return dy
+	}
+	s.wheel += dy
+	steps := math.Trunc(s.wheel)
+	s.wheel -= steps
+	return steps
+}
+
+// pageStep returns how far Page Up, Page Down and paging clicks move the value.
+func (s *Slider) pageStep() float64 {
+	if s.PageStep > 0 {
+		return s.PageStep
+	}
+	return max((s.Max-s.Min)/10, s.Step)
+}
+
+// thumbSize returns the diameter of the thumb.
+func (s *Slider) thumbSize() float32 {
+	if s.ThumbSize > 0 {
+		return s.ThumbSize
+	}
+	return min(s.Bounds.W, s.Bounds.H)
+}
+
+// travel returns the ends of the line the thumb's centre moves along: the
+// position of Min and of Max, along the slider's axis.
+func (s *Slider) travel() (start, end float32) {
+	half := s.thumbSize() / 2
+	if s.Vertical {
+		return s.Bounds.Y + s.Bounds.H - half, s.Bounds.Y + half
+	}
+	return s.Bounds.X + half, s.Bounds.X + s.Bounds.W - half
+}
+
+// position returns where value lies along the slider's axis.
+func (s *Slider) position(value float64) float32 {
+	start, end := s.travel()
+	if s.Max == s.Min {
+		return start
+	}
+	t := float32((value - s.Min) / (s.Max - s.Min))
+	return start + (end-start)*t
+}
+
+// valueAt returns the snapped value at a position along the slider's axis.
+func (s *Slider) valueAt(pos float32) float64 {
+	start, end := s.travel()
+	if start == end {
+		return s.snap(s.Min)
+	}
+	t := float64((pos - start) / (end - start))
+	return s.snap(s.Min + t*(s.Max-s.Min))
+}
+
+// axis returns the cursor's position along the slider's axis.
+func (s *Slider) axis(x, y float32) float32 {
+	if s.Vertical {
+		return y
+	}
+	return x
+}
+
+// change sets the value and calls OnChange if it moved, reporting whether it did.
+func (s *Slider) change(value float64) bool {
+	value = s.snap(value)
+	if value == s.Value {
+		return false
+	}
+	s.Value = value
+	if s.OnChange != nil {
+		s.OnChange(value)
+	}
+	return true
+}
+
+// commit is change for one-off changes: it also calls OnChangeEnd.
+func (s *Slider) commit(value float64) {
+	if s.change(value) && s.OnChangeEnd != nil {
+		s.OnChangeEnd(s.Value)
+	}
+}
+
+// Update should be called every frame.
+func (s *Slider) Update() {
+	if !s.Enabled {
+		s.hovered, s.IsDragging, s.IsActive = false, false, false
+		return
+	}
+
+	in := input.Or(s.Input)
+	mx, my := in.CursorPosition()
+	x, y := float32(mx), float32(my)
+	s.hovered = pointInRect(x, y, s.Bounds) && !overlay.Blocks(s, x, y)
+	s.handleMouse(in, x, y)
+
+	if _, dy := in.Wheel(); dy != 0 && s.hovered {
+		s.commit(s.Value + s.wheelSteps(dy)*s.keyStep())
+	}
+
+	s.repeater.Update(in, input.KeyRepeatOr(s.KeyRepeat), clock.Or(s.Clock).Delta())
+	if s.IsActive {
+		s.handleKeys(in)
+	}
+}
+
+// handleMouse starts, continues and ends drags, and handles clicks on the track.
+func (s *Slider) handleMouse(in input.Input, x, y float32) {
+	if in.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		s.IsActive = s.hovered
+		if s.hovered {
+			pos := s.axis(x, y)
+			thumb := s.position(s.Value)
+			switch {
+			case abs(pos-thumb) <= s.thumbSize()/2:
+				// Grabbing the thumb off-centre must not make it jump.
+				s.startDrag(pos - thumb)
+			case s.PageOnClick:
+				// Page towards the click: the axis runs backwards when vertical.
+				if (pos > thumb) != s.Vertical {
+					s.commit(s.Value + s.pageStep())
+				} else {
+					s.commit(s.Value - s.pageStep())
+				}
+			default:
+				s.startDrag(0)
+				s.change(s.valueAt(pos))
+			}
+		}
+	}
+
+	if !s.IsDragging {
+		return
+	}
+	if !in.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		s.IsDragging = false
+		if s.Value != s.dragStart && s.OnChangeEnd != nil {
+			s.OnChangeEnd(s.Value)
+		}
+		return
+	}
+	s.change(s.valueAt(s.axis(x, y) - s.grabOffset))
+}
+
+func (s *Slider) startDrag(offset float32) {
+	s.IsDragging = true
+	s.grabOffset = offset
+	s.dragStart = s.Value
+}
+
+// handleKeys moves the slider with the arrow, page, Home and End keys.
+func (s *Slider) handleKeys(in input.Input) {
+	for range s.repeater.Presses(in, ebiten.KeyRight) + s.repeater.Presses(in, ebiten.KeyUp) {
+		s.commit(s.Value + s.keyStep())
+	}
+	for range s.repeater.Presses(in, ebiten.KeyLeft) + s.repeater.Presses(in, ebiten.KeyDown) {
+		s.commit(s.Value - s.keyStep())
+	}
+	for range s.repeater.Presses(in, ebiten.KeyPageUp) {
+		s.commit(s.Value + s.pageStep())
+	}
+	for range s.repeater.Presses(in, ebiten.KeyPageDown) {
+		s.commit(s.Value - s.pageStep())
+	}
+	if in.IsKeyJustPressed(ebiten.KeyHome) {
+		s.commit(s.Min)
+	}
+	if in.IsKeyJustPressed(ebiten.KeyEnd) {
+		s.commit(s.Max)
+	}
+	if in.IsKeyJustPressed(ebiten.KeyEscape) {
+		s.IsActive = false
+	}
+}
+
+// Draw draws the track, ticks, thumb and value.
+func (s *Slider) Draw(screen *ebiten.Image) {
+	if s.Invisible {
+		return
+	}
+
+	start, end := s.travel()
+	thumb := s.position(s.Value)
+	thickness := s.TrackThickness
+	radii := draw.Uniform(thickness / 2)
+
+	// The track runs between the thumb's extremes, filled up to the thumb.
+	if s.Vertical {
+		cx := s.Bounds.X + s.Bounds.W/2
+		draw.FillRoundedRect(screen, cx-thickness/2, end, thickness, start-end, radii, s.color(s.TrackColor))
+		draw.FillRoundedRect(screen, cx-thickness/2, thumb, thickness, start-thumb, radii, s.color(s.FillColor))
+	} else {
+		cy := s.Bounds.Y + s.Bounds.H/2
+		draw.FillRoundedRect(screen, start, cy-thickness/2, end-start, thickness, radii, s.color(s.TrackColor))
+		draw.FillRoundedRect(screen, start, cy-thickness/2, thumb-start, thickness, radii, s.color(s.FillColor))
+	}
+
+	if s.ShowTicks {
+		s.drawTicks(screen)
+	}
+
+	thumbColor := s.ThumbColor
+	if s.hovered || s.IsDragging {
+		thumbColor = s.ThumbHoverColor
+	}
+	cx, cy := thumb, s.Bounds.Y+s.Bounds.H/2
+	if s.Vertical {
+		cx, cy = s.Bounds.X+s.Bounds.W/2, thumb
+	}
+	radius := s.thumbSize() / 2
+	draw.FillCircle(screen, cx, cy, radius, s.color(thumbColor))
+	border := float32(1.5)
+	if s.IsActive {
+		border = 2.5
+	}
+	draw.StrokeCircle(screen, cx, cy, radius-border/2, border, s.color(s.BorderColor))
+
+	if s.ShowValue && s.FontFace != nil {
+		label := s.formatValue()
+		textX := s.Bounds.X + s.Bounds.W + 8
+		textY := s.Bounds.Y + (s.Bounds.H-float32(s.FontSize))/2
+		if s.Vertical {
+			width := float32(text.BoundString(s.FontFace, label).Dx())
+			textX = s.Bounds.X + (s.Bounds.W-width)/2
+			textY = s.Bounds.Y + s.Bounds.H + 4
+		}
+		text.Draw(screen, label, s.FontFace, int(textX), int(textY)+int(s.FontSize), s.color(s.TextColor))
+	}
+}
+
+// drawTicks draws a mark across the track at every tick interval from Min.
+func (s *Slider) drawTicks(screen *ebiten.Image) {
+	interval := s.TickInterval
+	if interval <= 0 {
+		interval = s.Step
+	}
+	span := math.Abs(s.Max - s.Min)
+	if interval <= 0 || span/interval > maxTicks {
+		return
+	}
+	length := s.TrackThickness + 6
+	col := s.color(s.BorderColor)
+	for i := 0; float64(i)*interval <= span+interval*1e-9; i++ {
+		pos := s.position(s.Min + math.Copysign(float64(i)*interval, s.Max-s.Min))
+		if s.Vertical {
+			cx := s.Bounds.X + s.Bounds.W/2
+			draw.Line(screen, cx-length/2, pos, cx+length/2, pos, 1, col)
+		} else {
+			cy := s.Bounds.Y + s.Bounds.H/2
+			draw.Line(screen, pos, cy-length/2, pos, cy+length/2, 1, col)
+		}
+	}
+}
+
+// formatValue returns the value as drawn by ShowValue.
+func (s *Slider) formatValue() string {
+	if s.Format != nil {
+		return s.Format(s.Value)
+	}
+	return strconv.FormatFloat(s.Value, 'f', -1, 64)
+}
+
+// color fades c if the slider is disabled.
+func (s *Slider) color(c color.RGBA) color.RGBA {
+	if s.Enabled {
+		return c
+	}
+	return paint.Fade(c, 0.5)
+}
+
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
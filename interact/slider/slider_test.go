@@ -0,0 +1,193 @@
+package slider_test
+
+import (
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/slider"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/testutil"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// newVolume returns a 0-100 slider whose thumb travels from x=20 to x=220.
+func newVolume() *slider.Slider {
+	s := slider.New(10, 10, 220, 20, 0, 100)
+	s.SetRange(0, 100, 1)
+	return s
+}
+
+// recorder collects the values passed to OnChange and OnChangeEnd.
+type recorder struct {
+	changes, ends []float64
+}
+
+func record(s *slider.Slider) *recorder {
+	r := &recorder{}
+	s.OnChange = func(v float64) { r.changes = append(r.changes, v) }
+	s.OnChangeEnd = func(v float64) { r.ends = append(r.ends, v) }
+	return r
+}
+
+func TestDragThumb(t *testing.T) {
+	s := newVolume()
+	s.SetValue(50) // thumb at x=120
+	r := record(s)
+	h := testutil.NewHarness(s)
+	defer h.Close()
+
+	// Grab the thumb off-centre: it must not jump to the cursor.
+	h.Press(125, 20)
+	if s.Value != 50 || !s.IsDragging {
+		t.Fatalf("grabbing the thumb: value %v dragging %v, want 50 and dragging", s.Value, s.IsDragging)
+	}
+	h.MoveCursor(165, 20)
+	if s.Value != 70 {
+		t.Fatalf("value %v after dragging 40px, want 70", s.Value)
+	}
+	// Dragging past the end, even outside the bounds, clamps.
+	h.MoveCursor(400, 80)
+	if s.Value != 100 {
+		t.Fatalf("value %v after dragging past the end, want 100", s.Value)
+	}
+	h.Release(400, 80)
+	if s.IsDragging {
+		t.Fatal("still dragging after release")
+	}
+	if len(r.changes) != 2 || len(r.ends) != 1 || r.ends[0] != 100 {
+		t.Fatalf("changes %v ends %v, want two changes and one end at 100", r.changes, r.ends)
+	}
+}
+
+func TestClickTrackJumpsOrPages(t *testing.T) {
+	s := newVolume()
+	r := record(s)
+	h := testutil.NewHarness(s)
+	defer h.Close()
+
+	h.Click(170, 20)
+	if s.Value != 75 {
+		t.Fatalf("value %v after clicking the track, want a jump to 75", s.Value)
+	}
+	if len(r.ends) != 1 {
+		t.Fatalf("OnChangeEnd called %d times for a click, want once", len(r.ends))
+	}
+
+	s.SetPageOnClick(true)
+	h.Click(40, 20)
+	if s.Value != 65 {
+		t.Fatalf("value %v after clicking left of the thumb, want a page down to 65", s.Value)
+	}
+	s.SetPageStep(25)
+	h.Click(215, 20)
+	if s.Value != 90 {
+		t.Fatalf("value %v after clicking right of the thumb, want a page up to 90", s.Value)
+	}
+}
+
+func TestKeysWhenActive(t *testing.T) {
+	s := newVolume()
+	s.SetValue(50)
+	r := record(s)
+	h := testutil.NewHarness(s)
+	defer h.Close()
+
+	h.PressKeys(ebiten.KeyRight)
+	if s.Value != 50 {
+		t.Fatal("keys moved an inactive slider")
+	}
+
+	s.Activate()
+	h.PressKeys(ebiten.KeyRight)
+	h.PressKeys(ebiten.KeyUp)
+	h.PressKeys(ebiten.KeyLeft)
+	if s.Value != 51 {
+		t.Fatalf("value %v after right, up, left, want 51", s.Value)
+	}
+	h.PressKeys(ebiten.KeyPageDown)
+	if s.Value != 41 {
+		t.Fatalf("value %v after Page Down, want 41", s.Value)
+	}
+	h.PressKeys(ebiten.KeyEnd)
+	h.PressKeys(ebiten.KeyRight)
+	if s.Value != 100 {
+		t.Fatalf("value %v after End and right, want 100", s.Value)
+	}
+	h.PressKeys(ebiten.KeyHome)
+	if s.Value != 0 {
+		t.Fatalf("value %v after Home, want 0", s.Value)
+	}
+	// Each key press that moved the slider is a change of its own.
+	if len(r.changes) != 6 || len(r.ends) != 6 {
+		t.Fatalf("%d changes and %d ends, want 6 of each", len(r.changes), len(r.ends))
+	}
+}
+
+func TestWheelOverSlider(t *testing.T) {
+	s := newVolume()
+	h := testutil.NewHarness(s)
+	defer h.Close()
+
+	h.Input.MoveCursor(100, 20)
+	h.Input.Scroll(0, 3)
+	h.Frame()
+	if s.Value != 3 {
+		t.Fatalf("value %v after scrolling up 3 notches, want 3", s.Value)
+	}
+	h.Input.MoveCursor(100, 200)
+	h.Input.Scroll(0, 3)
+	h.Frame()
+	if s.Value != 3 {
+		t.Fatal("scrolling away from the slider moved it")
+	}
+
+	// A trackpad's fractions of a notch add up to whole steps.
+	h.Input.MoveCursor(100, 20)
+	for range 5 {
+		h.Input.Scroll(0, 0.25)
+		h.Frame()
+	}
+	if s.Value != 4 {
+		t.Fatalf("value %v after scrolling up 1.25 notches in quarters, want 4", s.Value)
+	}
+}
+
+func TestStepSnapping(t *testing.T) {
+	s := slider.New(0, 0, 100, 20, 0, 1)
+	s.SetRange(0, 1, 0.1)
+	s.SetValue(0.33)
+	if s.Value != 0.3 {
+		t.Fatalf("value %v, want 0.3 exactly", s.Value)
+	}
+	s.SetValue(7)
+	if s.Value != 1 {
+		t.Fatalf("value %v, want it clamped to 1", s.Value)
+	}
+}
+
+func TestVerticalIncreasesUpwards(t *testing.T) {
+	// Thumb travels from y=210 (0) up to y=10 (100).
+	s := slider.NewVertical(0, 0, 20, 220, 0, 100)
+	h := testutil.NewHarness(s)
+	defer h.Close()
+
+	h.Click(10, 60)
+	if s.Value != 75 {
+		t.Fatalf("value %v after clicking near the top, want 75", s.Value)
+	}
+	s.SetPageOnClick(true)
+	h.Click(10, 200)
+	if s.Value != 65 {
+		t.Fatalf("value %v after clicking below the thumb, want a page down to 65", s.Value)
+	}
+}
+
+func TestDisabledSliderIgnoresInput(t *testing.T) {
+	s := newVolume()
+	s.SetEnabled(false)
+	h := testutil.NewHarness(s)
+	defer h.Close()
+
+	h.Click(170, 20)
+	if s.Value != 0 {
+		t.Fatal("disabled slider moved")
+	}
+}
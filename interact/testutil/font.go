@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: MIT
+package testutil
+
+import (
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+// GoRegular returns the Go Regular font at size points and 72 DPI, so a point
+// is a pixel. Tests that measure text use it to get the same metrics on every
+// machine without loading a font file.
+func GoRegular(size float64) font.Face {
+	f, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		panic(err) // The font is embedded, so this only fails if it is corrupt.
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72})
+	if err != nil {
+		panic(err)
+	}
+	return face
+}
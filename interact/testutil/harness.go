@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+
+// Package testutil drives widgets headlessly: a FakeInput replaces ebiten's
+// input state and a Harness steps Update frame by frame, so widget behaviour can
+// be tested without opening a window or touching the GPU.
+package testutil
+
+import (
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/clock"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/overlay"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Updater is anything with a per-frame Update, which includes every
+// interact.InteractiveObject.
+type Updater interface {
+	Update()
+}
+
+// Harness owns a FakeInput installed as the default input, a fixed clock
+// installed as the default clock and a set of objects that are updated once per
+// simulated frame.
+type Harness struct {
+	Input   *FakeInput
+	Clock   *clock.Fixed // Frames last 1/60s unless Clock.Seconds is changed
+	Objects []Updater
+
+	prevInput input.Input
+	prevClock clock.Clock
+}
+
+// NewHarness installs a fresh FakeInput as input.Default() and a 60 frames per
+// second clock as clock.Default(), clears any popups left open by earlier
+// tests with overlay.Reset, and returns a harness driving the given objects.
+// Call Close to restore the previous input and clock.
+func NewHarness(objects ...Updater) *Harness {
+	h := &Harness{
+		Input:     NewFakeInput(),
+		Clock:     clock.NewFixed(1.0 / 60.0),
+		Objects:   objects,
+		prevInput: input.Default(),
+		prevClock: clock.Default(),
+	}
+	input.SetDefault(h.Input)
+	clock.SetDefault(h.Clock)
+	overlay.Reset()
+	return h
+}
+
+// Add registers more objects to be updated every frame.
+func (h *Harness) Add(objects ...Updater) {
+	h.Objects = append(h.Objects, objects...)
+}
+
+// Close restores the input and clock that were the defaults before NewHarness.
+func (h *Harness) Close() {
+	input.SetDefault(h.prevInput)
+	clock.SetDefault(h.prevClock)
+}
+
+// Frame runs a single frame: every object is updated, then the input rolls over.
+func (h *Harness) Frame() {
+	for _, obj := range h.Objects {
+		obj.Update()
+	}
+	h.Input.EndFrame()
+}
+
+// Advance runs n frames without changing the input.
+func (h *Harness) Advance(n int) {
+	for i := 0; i < n; i++ {
+		h.Frame()
+	}
+}
+
+// MoveCursor moves the cursor and runs a frame.
+func (h *Harness) MoveCursor(x, y int) {
+	h.Input.MoveCursor(x, y)
+	h.Frame()
+}
+
+// Press moves the cursor to (x, y), presses the left mouse button and runs a frame.
+func (h *Harness) Press(x, y int) {
+	h.Input.MoveCursor(x, y)
+	h.Input.PressButton(ebiten.MouseButtonLeft)
+	h.Frame()
+}
+
+// Release moves the cursor to (x, y), releases the left mouse button and runs a frame.
+func (h *Harness) Release(x, y int) {
+	h.Input.MoveCursor(x, y)
+	h.Input.ReleaseButton(ebiten.MouseButtonLeft)
+	h.Frame()
+}
+
+// Click presses and releases the left mouse button at (x, y) over two frames.
+func (h *Harness) Click(x, y int) {
+	h.Press(x, y)
+	h.Release(x, y)
+}
+
+// TypeString types s one rune per frame, the way a user typing would.
+func (h *Harness) TypeString(s string) {
+	for _, r := range s {
+		h.Input.Type(string(r))
+		h.Frame()
+	}
+}
+
+// PressKeys presses the keys together (e.g. KeyControl, KeyA), runs a frame,
+// then releases them and runs another frame.
+func (h *Harness) PressKeys(keys ...ebiten.Key) {
+	h.Input.PressKey(keys...)
+	h.Frame()
+	h.Input.ReleaseKey(keys...)
+	h.Frame()
+}
+
+// HoldKeys holds the keys down for the given number of frames, then releases them.
+func (h *Harness) HoldKeys(frames int, keys ...ebiten.Key) {
+	h.Input.PressKey(keys...)
+	h.Advance(frames)
+	h.Input.ReleaseKey(keys...)
+	h.Frame()
+}
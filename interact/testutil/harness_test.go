@@ -0,0 +1,89 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type recorder struct {
+	in      input.Input
+	pressed []bool
+	chars   []string
+}
+
+func (r *recorder) Update() {
+	in := input.Or(r.in)
+	r.pressed = append(r.pressed, in.IsKeyJustPressed(ebiten.KeyA))
+	r.chars = append(r.chars, string(in.AppendInputChars(nil)))
+}
+
+func TestHarnessInstallsAndRestoresDefault(t *testing.T) {
+	before := input.Default()
+	h := NewHarness()
+	if input.Default() != input.Input(h.Input) {
+		t.Fatal("harness input is not the default")
+	}
+	h.Close()
+	if input.Default() != before {
+		t.Fatal("Close did not restore the previous default")
+	}
+}
+
+func TestJustPressedLastsOneFrame(t *testing.T) {
+	r := &recorder{}
+	h := NewHarness(r)
+	defer h.Close()
+
+	h.HoldKeys(3, ebiten.KeyA)
+	want := []bool{true, false, false, false}
+	for i, got := range r.pressed {
+		if got != want[i] {
+			t.Fatalf("frame %d: just pressed = %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestTypeStringOneRunePerFrame(t *testing.T) {
+	r := &recorder{}
+	h := NewHarness(r)
+	defer h.Close()
+
+	h.TypeString("hé")
+	h.Frame()
+	want := []string{"h", "é", ""}
+	if len(r.chars) != len(want) {
+		t.Fatalf("got %d frames, want %d", len(r.chars), len(want))
+	}
+	for i := range want {
+		if r.chars[i] != want[i] {
+			t.Errorf("frame %d: chars = %q, want %q", i, r.chars[i], want[i])
+		}
+	}
+}
+
+func TestSidedModifierPressesGeneric(t *testing.T) {
+	f := NewFakeInput()
+	f.PressKey(ebiten.KeyControlLeft)
+	if !f.IsKeyPressed(ebiten.KeyControl) {
+		t.Error("KeyControl not pressed after pressing KeyControlLeft")
+	}
+	f.ReleaseKey(ebiten.KeyControlLeft)
+	if f.IsKeyPressed(ebiten.KeyControl) {
+		t.Error("KeyControl still pressed after release")
+	}
+}
+
+func TestGenericModifierStaysWhileEitherSideIsHeld(t *testing.T) {
+	f := NewFakeInput()
+	f.PressKey(ebiten.KeyControlLeft, ebiten.KeyControlRight)
+	f.ReleaseKey(ebiten.KeyControlLeft)
+	if !f.IsKeyPressed(ebiten.KeyControl) {
+		t.Error("KeyControl released while KeyControlRight is still held")
+	}
+	f.ReleaseKey(ebiten.KeyControlRight)
+	if f.IsKeyPressed(ebiten.KeyControl) {
+		t.Error("KeyControl still pressed after releasing both sides")
+	}
+}
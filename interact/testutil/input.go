@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: MIT
+package testutil
+
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// FakeInput is a scriptable input.Input. State changes made through its methods
+// are visible to widgets on the next Update; EndFrame rolls the state over so
+// that "just pressed" and "just released" edges only last a single frame.
+type FakeInput struct {
+	cursorX, cursorY int
+	wheelX, wheelY   float64
+	chars            []rune
+
+	buttons     map[ebiten.MouseButton]bool
+	prevButtons map[ebiten.MouseButton]bool
+	keys        map[ebiten.Key]bool
+	prevKeys    map[ebiten.Key]bool
+	touches     map[ebiten.TouchID][2]int
+}
+
+func NewFakeInput() *FakeInput {
+	return &FakeInput{
+		buttons:     map[ebiten.MouseButton]bool{},
+		prevButtons: map[ebiten.MouseButton]bool{},
+		keys:        map[ebiten.Key]bool{},
+		prevKeys:    map[ebiten.Key]bool{},
+		touches:     map[ebiten.TouchID][2]int{},
+	}
+}
+
+// MoveCursor moves the mouse cursor to (x, y).
+func (f *FakeInput) MoveCursor(x, y int) {
+	f.cursorX, f.cursorY = x, y
+}
+
+// PressButton holds down a mouse button until ReleaseButton is called.
+func (f *FakeInput) PressButton(button ebiten.MouseButton) {
+	f.buttons[button] = true
+}
+
+// ReleaseButton lets go of a mouse button.
+func (f *FakeInput) ReleaseButton(button ebiten.MouseButton) {
+	delete(f.buttons, button)
+}
+
+// PressKey holds down the given keys until ReleaseKey is called.
+func (f *FakeInput) PressKey(keys ...ebiten.Key) {
+	for _, k := range keys {
+		f.keys[k] = true
+		// Ebiten reports both the sided and the generic modifier as pressed.
+		if generic, ok := genericModifier(k); ok {
+			f.keys[generic] = true
+		}
+	}
+}
+
+// ReleaseKey lets go of the given keys. A generic modifier stays pressed while
+// the key on its other side is still held.
+func (f *FakeInput) ReleaseKey(keys ...ebiten.Key) {
+	for _, k := range keys {
+		delete(f.keys, k)
+		if generic, ok := genericModifier(k); ok && !f.sidePressed(generic) {
+			delete(f.keys, generic)
+		}
+	}
+}
+
+// sidePressed reports whether either sided key of a generic modifier is held.
+func (f *FakeInput) sidePressed(generic ebiten.Key) bool {
+	for k := range f.keys {
+		if g, ok := genericModifier(k); ok && g == generic {
+			return true
+		}
+	}
+	return false
+}
+
+// Type queues s to be reported as typed characters during the current frame.
+func (f *FakeInput) Type(s string) {
+	f.chars = append(f.chars, []rune(s)...)
+}
+
+// Scroll adds wheel movement to the current frame.
+func (f *FakeInput) Scroll(xoff, yoff float64) {
+	f.wheelX += xoff
+	f.wheelY += yoff
+}
+
+// Touch starts or moves the touch with the given ID.
+func (f *FakeInput) Touch(id ebiten.TouchID, x, y int) {
+	f.touches[id] = [2]int{x, y}
+}
+
+// ReleaseTouch ends the touch with the given ID.
+func (f *FakeInput) ReleaseTouch(id ebiten.TouchID) {
+	delete(f.touches, id)
+}
+
+// EndFrame finishes the current frame: held buttons and keys stop being
+// "just pressed", and typed characters and wheel movement are cleared.
+func (f *FakeInput) EndFrame() {
+	f.prevButtons = copyMap(f.buttons)
+	f.prevKeys = copyMap(f.keys)
+	f.chars = f.chars[:0]
+	f.wheelX, f.wheelY = 0, 0
+}
+
+func (f *FakeInput) CursorPosition() (int, int) {
+	return f.cursorX, f.cursorY
+}
+
+func (f *FakeInput) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	return f.buttons[button]
+}
+
+func (f *FakeInput) IsMouseButtonJustPressed(button ebiten.MouseButton) bool {
+	return f.buttons[button] && !f.prevButtons[button]
+}
+
+func (f *FakeInput) IsMouseButtonJustReleased(button ebiten.MouseButton) bool {
+	return !f.buttons[button] && f.prevButtons[button]
+}
+
+func (f *FakeInput) IsKeyPressed(key ebiten.Key) bool {
+	return f.keys[key]
+}
+
+func (f *FakeInput) IsKeyJustPressed(key ebiten.Key) bool {
+	return f.keys[key] && !f.prevKeys[key]
+}
+
+func (f *FakeInput) AppendInputChars(runes []rune) []rune {
+	return append(runes, f.chars...)
+}
+
+func (f *FakeInput) Wheel() (float64, float64) {
+	return f.wheelX, f.wheelY
+}
+
+func (f *FakeInput) AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID {
+	start := len(touches)
+	for id := range f.touches {
+		touches = append(touches, id)
+	}
+	slices.Sort(touches[start:])
+	return touches
+}
+
+func (f *FakeInput) TouchPosition(id ebiten.TouchID) (int, int) {
+	p := f.touches[id]
+	return p[0], p[1]
+}
+
+func genericModifier(k ebiten.Key) (ebiten.Key, bool) {
+	switch k {
+	case ebiten.KeyControlLeft, ebiten.KeyControlRight:
+		return ebiten.KeyControl, true
+	case ebiten.KeyShiftLeft, ebiten.KeyShiftRight:
+		return ebiten.KeyShift, true
+	case ebiten.KeyAltLeft, ebiten.KeyAltRight:
+		return ebiten.KeyAlt, true
+	case ebiten.KeyMetaLeft, ebiten.KeyMetaRight:
+		return ebiten.KeyMeta, true
+	}
+	return 0, false
+}
+
+func copyMap[K comparable](m map[K]bool) map[K]bool {
+	c := make(map[K]bool, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,11 @@
+package textarea
+
+// SetClipboard replaces the system clipboard with fake functions for the
+// duration of a test and returns a function restoring the real one.
+func SetClipboard(copyFn func(string) error, pasteFn func() (string, error)) (restore func()) {
+	prevCopy, prevPaste := copyClipboardText, pasteClipboardText
+	copyClipboardText, pasteClipboardText = copyFn, pasteFn
+	return func() {
+		copyClipboardText, pasteClipboardText = prevCopy, prevPaste
+	}
+}
@@ -0,0 +1,372 @@
+// SPDX-License-Identifier: MIT
+package textarea
+
+import (
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/textutil"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/overlay"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// layout returns the wrapped lines of the text, recomputing them only when
+// the text, the available width or the font changed.
+func (ta *TextArea) layout() []textutil.Line {
+	width := ta.Bounds.W - 2*padding - scrollbarWidth
+	if !ta.layoutValid || ta.layoutText != ta.Text || ta.layoutWidth != width || ta.layoutFace != ta.FontFace {
+		ta.lines = textutil.Wrap(ta.FontFace, ta.Text, width)
+		ta.layoutText = ta.Text
+		ta.layoutWidth = width
+		ta.layoutFace = ta.FontFace
+		ta.layoutValid = true
+	}
+	return ta.lines
+}
+
+// LineCount returns the number of visual lines after wrapping.
+func (ta *TextArea) LineCount() int {
+	return len(ta.layout())
+}
+
+func (ta *TextArea) lineHeight() float32 {
+	if ta.FontFace == nil {
+		return float32(ta.FontSize)
+	}
+	return float32(ta.FontFace.Metrics().Height.Ceil())
+}
+
+// lineTop returns the screen y of the top of line i.
+func (ta *TextArea) lineTop(i int) float32 {
+	return ta.Bounds.Y + padding + float32(i)*ta.lineHeight() - ta.ScrollY
+}
+
+func (ta *TextArea) viewHeight() float32 {
+	return ta.Bounds.H - 2*padding
+}
+
+func (ta *TextArea) contentHeight() float32 {
+	return float32(len(ta.layout())) * ta.lineHeight()
+}
+
+func (ta *TextArea) maxScroll() float32 {
+	return max(0, ta.contentHeight()-ta.viewHeight())
+}
+
+// visibleLines returns how many whole lines fit in the view.
+func (ta *TextArea) visibleLines() int {
+	return max(1, int(ta.viewHeight()/ta.lineHeight()))
+}
+
+// visibleLineRange returns the first and last line that are at least partly visible.
+func (ta *TextArea) visibleLineRange() (first, last int) {
+	lh := ta.lineHeight()
+	first = max(0, int(ta.ScrollY/lh))
+	last = min(len(ta.layout())-1, int((ta.ScrollY+ta.viewHeight())/lh))
+	return first, last
+}
+
+// ScrollBy scrolls the content by dy pixels, clamped to the content.
+func (ta *TextArea) ScrollBy(dy float32) {
+	ta.ScrollY = clamp32(ta.ScrollY+dy, 0, ta.maxScroll())
+}
+
+// ScrollToTop scrolls to the first line.
+func (ta *TextArea) ScrollToTop() {
+	ta.ScrollY = 0
+}
+
+// ScrollToBottom scrolls to the last line.
+func (ta *TextArea) ScrollToBottom() {
+	ta.ScrollY = ta.maxScroll()
+}
+
+// ensureCursorVisible scrolls just enough to bring the cursor's line into view.
+func (ta *TextArea) ensureCursorVisible() {
+	lh := ta.lineHeight()
+	top := float32(ta.cursorLine()) * lh
+	if top < ta.ScrollY {
+		ta.ScrollY = top
+	} else if top+lh > ta.ScrollY+ta.viewHeight() {
+		ta.ScrollY = top + lh - ta.viewHeight()
+	}
+	ta.ScrollY = clamp32(ta.ScrollY, 0, ta.maxScroll())
+}
+
+// lineOf returns the line containing the byte offset. An offset where a line
+// was soft wrapped belongs to the following line.
+func (ta *TextArea) lineOf(offset int) int {
+	lines := ta.layout()
+	i := len(lines) - 1
+	for i > 0 && lines[i].Start > offset {
+		i--
+	}
+	return i
+}
+
+func (ta *TextArea) cursorLine() int {
+	return ta.lineOf(ta.offset(ta.CursorPosition))
+}
+
+// cursorCoords returns the screen x of the cursor and the top of its line.
+func (ta *TextArea) cursorCoords() (x, top float32) {
+	offset := ta.offset(ta.CursorPosition)
+	i := ta.lineOf(offset)
+	line := ta.layout()[i]
+	return ta.Bounds.X + padding + textutil.Width(ta.FontFace, ta.Text[line.Start:offset]), ta.lineTop(i)
+}
+
+// softWrapped reports whether line i continues on the next line without a newline.
+func (ta *TextArea) softWrapped(i int) bool {
+	lines := ta.layout()
+	return i+1 < len(lines) && lines[i+1].Start == lines[i].End
+}
+
+// lineCandidates returns the byte offsets the cursor can take on line i and
+// their x positions relative to the start of the line. The end of a soft
+// wrapped line is left out because it displays on the next line.
+func (ta *TextArea) lineCandidates(i int) (offsets []int, xs []float32) {
+	line := ta.layout()[i]
+	lineText := ta.Text[line.Start:line.End]
+	for _, b := range textutil.Boundaries(lineText) {
+		offsets = append(offsets, line.Start+b)
+	}
+	xs = textutil.CaretPositions(ta.FontFace, lineText)
+	if ta.softWrapped(i) && len(offsets) > 1 {
+		offsets = offsets[:len(offsets)-1]
+		xs = xs[:len(xs)-1]
+	}
+	return offsets, xs
+}
+
+// offsetAtX returns the cursor offset on line i closest to the screen x.
+func (ta *TextArea) offsetAtX(i int, x float32) int {
+	offsets, xs := ta.lineCandidates(i)
+	return offsets[textutil.Nearest(xs, x-(ta.Bounds.X+padding))]
+}
+
+// positionAt returns the grapheme position closest to the screen point.
+func (ta *TextArea) positionAt(x, y float32) int {
+	i := int((y - ta.Bounds.Y - padding + ta.ScrollY) / ta.lineHeight())
+	i = clamp(i, 0, len(ta.layout())-1)
+	return textutil.Index(ta.Text, ta.offsetAtX(i, x))
+}
+
+// moveVertical moves the cursor n lines down (or up for negative n), keeping
+// the column it had before the first vertical move.
+func (ta *TextArea) moveVertical(n int, extend bool) {
+	x, _ := ta.cursorCoords()
+	if !ta.hasPreferredX {
+		ta.preferredX = x
+	}
+	target := ta.cursorLine() + n
+	var pos int
+	switch {
+	case target < 0:
+		pos = 0
+	case target >= len(ta.layout()):
+		pos = ta.length()
+	default:
+		pos = textutil.Index(ta.Text, ta.offsetAtX(target, ta.preferredX))
+	}
+	preferredX := ta.preferredX
+	ta.moveCursor(pos, extend)
+	ta.preferredX = preferredX
+	ta.hasPreferredX = true
+}
+
+// lineStartPosition returns the position at the start of the cursor's line.
+func (ta *TextArea) lineStartPosition() int {
+	return textutil.Index(ta.Text, ta.layout()[ta.cursorLine()].Start)
+}
+
+// lineEndPosition returns the last position on the cursor's line.
+func (ta *TextArea) lineEndPosition() int {
+	offsets, _ := ta.lineCandidates(ta.cursorLine())
+	return textutil.Index(ta.Text, offsets[len(offsets)-1])
+}
+
+// scrollbarRects returns the scrollbar track and thumb, or false when all
+// of the content fits and no scrollbar is shown.
+func (ta *TextArea) scrollbarRects() (track, thumb Rect, ok bool) {
+	content := ta.contentHeight()
+	view := ta.viewHeight()
+	if content <= view {
+		return Rect{}, Rect{}, false
+	}
+	track = NewRect(ta.Bounds.X+ta.Bounds.W-scrollbarWidth-2, ta.Bounds.Y+2, scrollbarWidth, ta.Bounds.H-4)
+	thumbH := max(minThumbHeight, track.H*view/content)
+	thumbY := track.Y + (track.H-thumbH)*ta.ScrollY/ta.maxScroll()
+	thumb = NewRect(track.X, thumbY, track.W, thumbH)
+	return track, thumb, true
+}
+
+// handleMouse activates the area on click, places the cursor, extends the
+// selection while dragging and drags the scrollbar thumb.
+func (ta *TextArea) handleMouse(in input.Input) {
+	mx, my := in.CursorPosition()
+	x, y := float32(mx), float32(my)
+
+	if in.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if !pointInRect(x, y, ta.Bounds) || overlay.Blocks(ta, x, y) {
+			ta.IsActive = false
+			ta.dragging = false
+			return
+		}
+		ta.IsActive = true
+
+		if track, thumb, ok := ta.scrollbarRects(); ok && x >= track.X {
+			ta.draggingScrollbar = true
+			if pointInRect(x, y, thumb) {
+				ta.scrollGrabOffset = y - thumb.Y
+			} else {
+				// Clicking the track centers the thumb on the pointer.
+				ta.scrollGrabOffset = thumb.H / 2
+				ta.dragScrollbar(y)
+			}
+			return
+		}
+
+		ta.moveCursor(ta.positionAt(x, y), in.IsKeyPressed(ebiten.KeyShift))
+		ta.dragging = true
+		return
+	}
+
+	if !in.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		ta.dragging = false
+		ta.draggingScrollbar = false
+		return
+	}
+	if ta.draggingScrollbar {
+		ta.dragScrollbar(y)
+	} else if ta.dragging {
+		ta.CursorPosition = ta.positionAt(x, y)
+		ta.ensureCursorVisible()
+	}
+}
+
+// dragScrollbar scrolls so the thumb follows the pointer at y.
+func (ta *TextArea) dragScrollbar(y float32) {
+	track, thumb, ok := ta.scrollbarRects()
+	if !ok {
+		return
+	}
+	free := track.H - thumb.H
+	if free <= 0 {
+		return
+	}
+	ta.ScrollY = clamp32((y-ta.scrollGrabOffset-track.Y)/free*ta.maxScroll(), 0, ta.maxScroll())
+}
+
+// Selection returns the selected range in order. Both ends are equal when nothing is selected.
+func (ta *TextArea) Selection() (start, end int) {
+	if ta.SelectionAnchor < ta.CursorPosition {
+		return ta.SelectionAnchor, ta.CursorPosition
+	}
+	return ta.CursorPosition, ta.SelectionAnchor
+}
+
+// HasSelection reports whether any text is selected.
+func (ta *TextArea) HasSelection() bool {
+	return ta.SelectionAnchor != ta.CursorPosition
+}
+
+// SelectedText returns the currently selected text.
+func (ta *TextArea) SelectedText() string {
+	start, end := ta.Selection()
+	return ta.Text[ta.offset(start):ta.offset(end)]
+}
+
+// SetSelection selects the text between anchor and cursor, leaving the cursor at cursor.
+func (ta *TextArea) SetSelection(anchor, cursor int) {
+	ta.SelectionAnchor = clamp(anchor, 0, ta.length())
+	ta.CursorPosition = clamp(cursor, 0, ta.length())
+	ta.hasPreferredX = false
+}
+
+// SelectAll selects the whole text with the cursor at the end.
+func (ta *TextArea) SelectAll() {
+	ta.SetSelection(0, ta.length())
+}
+
+// ClearSelection collapses the selection onto the cursor.
+func (ta *TextArea) ClearSelection() {
+	ta.SelectionAnchor = ta.CursorPosition
+}
+
+// moveCursor moves the cursor to pos, extending the selection if extend is set
+// and collapsing it otherwise.
+func (ta *TextArea) moveCursor(pos int, extend bool) {
+	ta.CursorPosition = pos
+	if !extend {
+		ta.SelectionAnchor = pos
+	}
+	ta.CursorBlinkTimer = 0.0
+	ta.hasPreferredX = false
+}
+
+func (ta *TextArea) clampSelection() {
+	n := ta.length()
+	ta.CursorPosition = clamp(ta.CursorPosition, 0, n)
+	ta.SelectionAnchor = clamp(ta.SelectionAnchor, 0, n)
+}
+
+// length returns the length of the text in grapheme clusters.
+func (ta *TextArea) length() int {
+	return textutil.GraphemeCount(ta.Text)
+}
+
+// offset returns the byte offset in Text of the grapheme position pos.
+func (ta *TextArea) offset(pos int) int {
+	return textutil.Offset(ta.Text, pos)
+}
+
+// insert inserts s at the cursor and moves the cursor after it, unless the
+// result would be longer than MaxLength.
+func (ta *TextArea) insert(s string) {
+	at := ta.offset(ta.CursorPosition)
+	result := ta.Text[:at] + s + ta.Text[at:]
+	if ta.MaxLength > 0 && textutil.GraphemeCount(result) > ta.MaxLength {
+		return
+	}
+	ta.Text = result
+	ta.moveCursor(textutil.Index(ta.Text, at+len(s)), false)
+}
+
+// deleteSelection removes the selected text and reports whether there was any.
+func (ta *TextArea) deleteSelection() bool {
+	if !ta.HasSelection() {
+		return false
+	}
+	start, end := ta.Selection()
+	ta.Text = ta.Text[:ta.offset(start)] + ta.Text[ta.offset(end):]
+	ta.moveCursor(start, false)
+	return true
+}
+
+// backspace deletes the selection, or the character before the cursor if nothing is selected.
+func (ta *TextArea) backspace() {
+	if ta.deleteSelection() || ta.CursorPosition == 0 {
+		return
+	}
+	ta.Text = ta.Text[:ta.offset(ta.CursorPosition-1)] + ta.Text[ta.offset(ta.CursorPosition):]
+	ta.moveCursor(ta.CursorPosition-1, false)
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+func clamp32(v, lo, hi float32) float32 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
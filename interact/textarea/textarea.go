@@ -0,0 +1,466 @@
+// SPDX-License-Identifier: MIT
+package textarea
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/clip"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/clock"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/draw"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/paint"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/textutil"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/overlay"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// Rect defines a rectangle with float32 coordinates.
+type Rect struct {
+	X, Y, W, H float32
+}
+
+func NewRect(x, y, w, h float32) Rect {
+	return Rect{x, y, w, h}
+}
+
+// Clipboard access goes through these variables so tests can replace it.
+var (
+	copyClipboardText  = clip.CopyClip
+	pasteClipboardText = clip.PasteClip
+)
+
+func pointInRect(x, y float32, r Rect) bool {
+	return x >= r.X && x <= r.X+r.W && y >= r.Y && y <= r.Y+r.H
+}
+
+const (
+	// padding is the space between the border and the text.
+	padding = float32(5)
+	// scrollbarWidth is the width reserved for the vertical scrollbar on the right.
+	scrollbarWidth = float32(8)
+	// minThumbHeight keeps the scrollbar thumb grabbable for very long texts.
+	minThumbHeight = float32(16)
+	// wheelLines is how many lines one wheel step scrolls.
+	wheelLines = 3
+)
+
+// DefaultFont is a package-level font face used for drawing text.
+// Set this to a valid font.Face during initialization.
+var DefaultFont font.Face
+
+// TextArea is a multi-line text field. Text is soft wrapped to the width of
+// its bounds and scrolls vertically when it does not fit.
+type TextArea struct {
+	Bounds             Rect
+	Text               string
+	MaxLength          int // Maximum length in grapheme clusters; zero or less is unlimited
+	BackgroundColor    color.RGBA
+	BorderColor        color.RGBA
+	TextColor          color.RGBA
+	SelectionColor     color.RGBA
+	ScrollbarColor     color.RGBA
+	FontSize           int32
+	FontFace           font.Face
+	IsActive           bool
+	CursorPosition     int // Caret position, counted in grapheme clusters
+	SelectionAnchor    int // The end of the selection opposite CursorPosition
+	CursorBlinkTimer   float32
+	BackspaceHoldTimer float32 // Deprecated: use KeyRepeat. A positive value is the repeat delay while KeyRepeat is nil
+	ScrollY            float32 // How far the content is scrolled down, in pixels
+	Invisible          bool
+	Uneditable         bool
+	ReadOnly           bool // Text can be selected, scrolled and copied but not changed
+	Placeholder        string
+	Input              input.Input      // Input source; nil uses input.Default()
+	Keymap             *input.Keymap    // Shortcut modifiers; nil uses input.DefaultKeymap()
+	KeyRepeat          *input.KeyRepeat // Timing of held keys; nil uses input.DefaultKeyRepeat()
+	Clock              clock.Clock      // Frame timing for blinking and key repeat; nil uses clock.Default()
+
+	repeater input.Repeater
+
+	// preferredX is the caret x kept while moving up and down, so passing
+	// through a short line does not lose the column.
+	preferredX    float32
+	hasPreferredX bool
+
+	dragging          bool
+	draggingScrollbar bool
+	scrollGrabOffset  float32
+
+	// Wrapped lines, recomputed when the text, width or font changes.
+	lines       []textutil.Line
+	layoutText  string
+	layoutWidth float32
+	layoutFace  font.Face
+	layoutValid bool
+}
+
+func NewTextArea(x, y, width, height float32, maxLength int) *TextArea {
+	return &TextArea{
+		Bounds:             NewRect(x, y, width, height),
+		Text:               "",
+		MaxLength:          maxLength,
+		BackgroundColor:    color.RGBA{R: 255, G: 255, B: 255, A: 255}, // White
+		BorderColor:        color.RGBA{R: 0, G: 0, B: 0, A: 255},       // Black
+		TextColor:          color.RGBA{R: 0, G: 0, B: 0, A: 255},       // Black
+		SelectionColor:     color.RGBA{R: 173, G: 214, B: 255, A: 255}, // Light blue
+		ScrollbarColor:     color.RGBA{R: 160, G: 160, B: 160, A: 255}, // Gray
+		FontSize:           20,
+		FontFace:           DefaultFont,
+		IsActive:           false,
+		CursorPosition:     0,
+		SelectionAnchor:    0,
+		CursorBlinkTimer:   0.0,
+		BackspaceHoldTimer: 0.0,
+		ScrollY:            0.0,
+		Invisible:          false,
+		Uneditable:         false,
+		ReadOnly:           false,
+		Placeholder:        "",
+	}
+}
+
+func (ta *TextArea) SetColors(background, border, text color.RGBA) {
+	ta.BackgroundColor = background
+	ta.BorderColor = border
+	ta.TextColor = text
+}
+
+func (ta *TextArea) SetFontSize(fontSize int32) {
+	ta.FontSize = fontSize
+}
+
+func (ta *TextArea) SetFont(face font.Face) {
+	ta.FontFace = face
+}
+
+func (ta *TextArea) SetInvisible(invisible bool) {
+	ta.Invisible = invisible
+}
+
+func (ta *TextArea) IsInvisible() bool {
+	return ta.Invisible
+}
+
+func (ta *TextArea) SetUneditable(uneditable bool) {
+	ta.Uneditable = uneditable
+}
+
+func (ta *TextArea) IsUneditable() bool {
+	return ta.Uneditable
+}
+
+// SetReadOnly makes the text selectable, scrollable and copyable but not editable.
+func (ta *TextArea) SetReadOnly(readOnly bool) {
+	ta.ReadOnly = readOnly
+}
+
+func (ta *TextArea) IsReadOnly() bool {
+	return ta.ReadOnly
+}
+
+func (ta *TextArea) SetPlaceholder(placeholder string) {
+	ta.Placeholder = placeholder
+}
+
+// SetInput sets the input source the text area reads from. Passing nil uses input.Default().
+func (ta *TextArea) SetInput(in input.Input) {
+	ta.Input = in
+}
+
+// SetKeymap sets the modifier keys used for shortcuts. Passing nil uses input.DefaultKeymap().
+func (ta *TextArea) SetKeymap(keymap *input.Keymap) {
+	ta.Keymap = keymap
+}
+
+// SetKeyRepeat sets how held keys repeat. Passing nil uses input.DefaultKeyRepeat().
+func (ta *TextArea) SetKeyRepeat(repeat *input.KeyRepeat) {
+	ta.KeyRepeat = repeat
+}
+
+// keyRepeat returns the timing of held keys, taking the deprecated
+// BackspaceHoldTimer as the delay.
+func (ta *TextArea) keyRepeat() input.KeyRepeat {
+	timing := input.KeyRepeatOr(ta.KeyRepeat)
+	if ta.KeyRepeat == nil && ta.BackspaceHoldTimer > 0 {
+		timing.Delay = ta.BackspaceHoldTimer
+	}
+	return timing
+}
+
+// SetClock sets the clock timers and key repeat are measured with. Passing nil uses clock.Default().
+func (ta *TextArea) SetClock(c clock.Clock) {
+	ta.Clock = c
+}
+
+func (ta *TextArea) GetText() string {
+	return ta.Text
+}
+
+// SetValue replaces the text, truncated to MaxLength, and moves the cursor to the end.
+func (ta *TextArea) SetValue(value string) {
+	if ta.MaxLength > 0 {
+		value = textutil.Truncate(value, ta.MaxLength)
+	}
+	ta.Text = value
+	ta.CursorPosition = ta.length()
+	ta.SelectionAnchor = ta.CursorPosition
+	ta.hasPreferredX = false
+}
+
+// AppendText adds s to the end of the text without moving the cursor, which
+// suits chat logs. If the view was scrolled to the bottom it stays there.
+func (ta *TextArea) AppendText(s string) {
+	atBottom := ta.ScrollY >= ta.maxScroll()-1
+	result := ta.Text + s
+	if ta.MaxLength > 0 {
+		result = textutil.Truncate(result, ta.MaxLength)
+	}
+	ta.Text = result
+	if atBottom {
+		ta.ScrollToBottom()
+	}
+}
+
+func (ta *TextArea) Activate() {
+	ta.IsActive = true
+	ta.CursorPosition = ta.length()
+	ta.SelectionAnchor = ta.CursorPosition
+	ta.ensureCursorVisible()
+}
+
+func (ta *TextArea) Deactivate() {
+	ta.IsActive = false
+	ta.dragging = false
+	ta.draggingScrollbar = false
+}
+
+// Update should be called every frame.
+func (ta *TextArea) Update() {
+	if ta.Uneditable {
+		return
+	}
+
+	// Update cursor blink timer.
+	dt := clock.Or(ta.Clock).Delta()
+	ta.CursorBlinkTimer += dt
+	if ta.CursorBlinkTimer >= 1.0 {
+		ta.CursorBlinkTimer -= 1.0
+	}
+
+	in := input.Or(ta.Input)
+	ta.clampSelection()
+
+	ta.handleMouse(in)
+
+	// Scroll with the mouse wheel while the cursor is over the text area.
+	mx, my := in.CursorPosition()
+	if _, dy := in.Wheel(); dy != 0 && pointInRect(float32(mx), float32(my), ta.Bounds) && !overlay.Blocks(ta, float32(mx), float32(my)) {
+		ta.ScrollBy(-float32(dy) * wheelLines * ta.lineHeight())
+	}
+
+	if !ta.IsActive {
+		return
+	}
+
+	before, beforeCursor := ta.Text, ta.CursorPosition
+	ta.handleKeys(in, dt)
+	if ta.Text != before || ta.CursorPosition != beforeCursor {
+		ta.ensureCursorVisible()
+	}
+}
+
+func (ta *TextArea) handleKeys(in input.Input, dt float32) {
+	shift := in.IsKeyPressed(ebiten.KeyShift)
+	keymap := input.KeymapOr(ta.Keymap)
+	shortcut := in.IsKeyPressed(keymap.Shortcut)
+	editable := !ta.ReadOnly
+
+	// Append typed characters, replacing the selection.
+	if chars := in.AppendInputChars(nil); len(chars) > 0 && editable {
+		ta.deleteSelection()
+		for _, ch := range chars {
+			ta.insert(string(ch))
+		}
+	}
+
+	// Editing and movement keys repeat while held.
+	ta.repeater.Update(in, ta.keyRepeat(), dt)
+
+	if editable {
+		// Enter starts a new line.
+		newlines := ta.repeater.Presses(in, ebiten.KeyEnter) + ta.repeater.Presses(in, ebiten.KeyNumpadEnter)
+		for range newlines {
+			ta.deleteSelection()
+			ta.insert("\n")
+		}
+
+		// Handle backspace.
+		for range ta.repeater.Presses(in, ebiten.KeyBackspace) {
+			ta.backspace()
+		}
+	}
+
+	// Left and right move by one character. Without shift a selection collapses.
+	for range ta.repeater.Presses(in, ebiten.KeyArrowLeft) {
+		if start, _ := ta.Selection(); ta.HasSelection() && !shift {
+			ta.moveCursor(start, false)
+		} else if ta.CursorPosition > 0 {
+			ta.moveCursor(ta.CursorPosition-1, shift)
+		}
+	}
+	for range ta.repeater.Presses(in, ebiten.KeyArrowRight) {
+		if _, end := ta.Selection(); ta.HasSelection() && !shift {
+			ta.moveCursor(end, false)
+		} else if ta.CursorPosition < ta.length() {
+			ta.moveCursor(ta.CursorPosition+1, shift)
+		}
+	}
+
+	// Up, down, page up and page down keep the preferred column.
+	for range ta.repeater.Presses(in, ebiten.KeyArrowUp) {
+		ta.moveVertical(-1, shift)
+	}
+	for range ta.repeater.Presses(in, ebiten.KeyArrowDown) {
+		ta.moveVertical(1, shift)
+	}
+	for range ta.repeater.Presses(in, ebiten.KeyPageUp) {
+		ta.moveVertical(-ta.visibleLines(), shift)
+	}
+	for range ta.repeater.Presses(in, ebiten.KeyPageDown) {
+		ta.moveVertical(ta.visibleLines(), shift)
+	}
+
+	// Home and End go to the start and end of the line, or of the whole text
+	// with the shortcut modifier held.
+	if in.IsKeyJustPressed(ebiten.KeyHome) {
+		if shortcut {
+			ta.moveCursor(0, shift)
+		} else {
+			ta.moveCursor(ta.lineStartPosition(), shift)
+		}
+	}
+	if in.IsKeyJustPressed(ebiten.KeyEnd) {
+		if shortcut {
+			ta.moveCursor(ta.length(), shift)
+		} else {
+			ta.moveCursor(ta.lineEndPosition(), shift)
+		}
+	}
+
+	// Handle Control+A (select all).
+	if keymap.IsShortcut(in, ebiten.KeyA) {
+		ta.SelectAll()
+	}
+
+	// Handle Control+C (copy).
+	if keymap.IsShortcut(in, ebiten.KeyC) && ta.HasSelection() {
+		ta.copySelection()
+	}
+
+	// Handle Control+X (cut). Read-only areas copy instead.
+	if keymap.IsShortcut(in, ebiten.KeyX) && ta.HasSelection() {
+		ta.copySelection()
+		if editable {
+			ta.deleteSelection()
+		}
+	}
+
+	// Handle Control+V (paste), replacing the selection.
+	if !editable {
+		return
+	}
+	for range ta.repeater.Shortcut(in, keymap, ebiten.KeyV) {
+		clipboardText, err := pasteClipboardText()
+		if err != nil {
+			panic(fmt.Errorf("failed to paste text from clipboard (from ebiten-interactive textarea): %w", err))
+		}
+		if clipboardText != "" {
+			ta.deleteSelection()
+			if ta.MaxLength > 0 {
+				clipboardText = textutil.Truncate(clipboardText, ta.MaxLength-ta.length())
+			}
+			ta.insert(clipboardText)
+		}
+	}
+}
+
+func (ta *TextArea) copySelection() {
+	if err := copyClipboardText(ta.SelectedText()); err != nil {
+		panic(fmt.Errorf("failed to copy text to clipboard (from ebiten-interactive textarea): %w", err))
+	}
+}
+
+// Draw draws the text area onto the given screen.
+func (ta *TextArea) Draw(screen *ebiten.Image) {
+	if ta.Invisible {
+		return
+	}
+
+	// Draw background.
+	draw.FillRect(screen, ta.Bounds.X, ta.Bounds.Y, ta.Bounds.W, ta.Bounds.H, ta.BackgroundColor)
+
+	// Border color: red if active.
+	drawBorderColor := ta.BorderColor
+	if ta.IsActive {
+		drawBorderColor = color.RGBA{R: 255, G: 0, B: 0, A: 255} // Red
+	}
+	draw.StrokeRect(screen, ta.Bounds.X, ta.Bounds.Y, ta.Bounds.W, ta.Bounds.H, 2, drawBorderColor)
+
+	if ta.FontFace == nil {
+		return
+	}
+
+	// Everything inside the border is clipped to the bounds.
+	clipRect := image.Rect(int(ta.Bounds.X), int(ta.Bounds.Y), int(ta.Bounds.X+ta.Bounds.W), int(ta.Bounds.Y+ta.Bounds.H))
+	content := screen.SubImage(clipRect).(*ebiten.Image)
+
+	lineHeight := ta.lineHeight()
+	ascent := float32(ta.FontFace.Metrics().Ascent.Ceil())
+	textX := ta.Bounds.X + padding
+
+	if ta.Text == "" && ta.Placeholder != "" {
+		text.Draw(content, ta.Placeholder, ta.FontFace, int(textX), int(ta.lineTop(0)+ascent), color.RGBA{R: 128, G: 128, B: 128, A: 255})
+	}
+
+	selStart, selEnd := ta.Selection()
+	selStartOffset, selEndOffset := ta.offset(selStart), ta.offset(selEnd)
+	first, last := ta.visibleLineRange()
+	lines := ta.layout()
+	for i := first; i <= last; i++ {
+		line := lines[i]
+		top := ta.lineTop(i)
+
+		// Highlight the selected part of the line, including its newline.
+		if selStartOffset < selEndOffset && selStartOffset <= line.End && selEndOffset >= line.Start {
+			from := max(selStartOffset, line.Start)
+			to := min(selEndOffset, line.End)
+			x0 := textX + textutil.Width(ta.FontFace, ta.Text[line.Start:from])
+			x1 := textX + textutil.Width(ta.FontFace, ta.Text[line.Start:to])
+			if selEndOffset > line.End && line.End < len(ta.Text) && ta.Text[line.End] == '\n' {
+				x1 += textutil.Width(ta.FontFace, " ")
+			}
+			if x1 > x0 {
+				draw.FillRect(content, x0, top, x1-x0, lineHeight, ta.SelectionColor)
+			}
+		}
+
+		text.Draw(content, ta.Text[line.Start:line.End], ta.FontFace, int(textX), int(top+ascent), ta.TextColor)
+	}
+
+	// Draw the cursor if active and during the blink phase.
+	if ta.IsActive && ta.CursorBlinkTimer < 0.5 {
+		x, top := ta.cursorCoords()
+		draw.Line(content, x, top, x, top+lineHeight, 1, ta.TextColor)
+	}
+
+	// Draw the scrollbar when the content does not fit.
+	if track, thumb, ok := ta.scrollbarRects(); ok {
+		draw.FillRect(content, track.X, track.Y, track.W, track.H, paint.Fade(ta.ScrollbarColor, 0.25))
+		draw.FillRect(content, thumb.X, thumb.Y, thumb.W, thumb.H, ta.ScrollbarColor)
+	}
+}
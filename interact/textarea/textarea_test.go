@@ -0,0 +1,182 @@
+package textarea_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/testutil"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/textarea"
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font/basicfont"
+)
+
+// newArea returns an active text area using a 7x13 font whose wrap width fits
+// exactly 10 characters and whose view shows 3 lines. Line i starts at
+// y = 15 + 13*i and character boundary c sits at x = 15 + 7*c.
+func newArea(t *testing.T, value string) (*textarea.TextArea, *testutil.Harness) {
+	t.Helper()
+	ta := textarea.NewTextArea(10, 10, 88, 49, 0)
+	ta.SetFont(basicfont.Face7x13)
+	keymap := input.PCKeymap()
+	ta.SetKeymap(&keymap)
+	ta.SetValue(value)
+	ta.Activate()
+	h := testutil.NewHarness(ta)
+	t.Cleanup(h.Close)
+	return ta, h
+}
+
+func TestEnterInsertsNewline(t *testing.T) {
+	ta, h := newArea(t, "")
+	h.TypeString("one")
+	h.PressKeys(ebiten.KeyEnter)
+	h.TypeString("two")
+	if ta.Text != "one\ntwo" || ta.LineCount() != 2 {
+		t.Fatalf("got %q with %d lines", ta.Text, ta.LineCount())
+	}
+}
+
+func TestWordWrap(t *testing.T) {
+	ta, _ := newArea(t, "the quick brown fox jumps")
+	if n := ta.LineCount(); n != 3 {
+		t.Fatalf("%d lines, want 3", n)
+	}
+}
+
+func TestUpDownKeepsColumn(t *testing.T) {
+	ta, h := newArea(t, "abcdefgh\nab\nabcdefgh")
+	ta.SetSelection(7, 7) // column 7 of the first line
+
+	h.PressKeys(ebiten.KeyArrowDown)
+	if ta.CursorPosition != 11 {
+		t.Fatalf("down onto short line: cursor %d, want 11", ta.CursorPosition)
+	}
+	h.PressKeys(ebiten.KeyArrowDown)
+	if ta.CursorPosition != 19 {
+		t.Fatalf("down again: cursor %d, want 19 (column 7 kept)", ta.CursorPosition)
+	}
+	h.PressKeys(ebiten.KeyShift, ebiten.KeyArrowUp)
+	h.PressKeys(ebiten.KeyShift, ebiten.KeyArrowUp)
+	if got := ta.SelectedText(); got != "h\nab\nabcdefg" {
+		t.Fatalf("shift+up selected %q", got)
+	}
+}
+
+func TestHomeEndOnWrappedLine(t *testing.T) {
+	ta, h := newArea(t, "the quick brown fox")
+	ta.SetSelection(12, 12) // inside "brown"
+
+	h.PressKeys(ebiten.KeyHome)
+	if ta.CursorPosition != 10 {
+		t.Fatalf("home: cursor %d, want 10", ta.CursorPosition)
+	}
+	h.PressKeys(ebiten.KeyArrowUp)
+	h.PressKeys(ebiten.KeyEnd)
+	if ta.CursorPosition != 9 {
+		t.Fatalf("end of soft wrapped line: cursor %d, want 9", ta.CursorPosition)
+	}
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyEnd)
+	if ta.CursorPosition != 19 {
+		t.Fatalf("ctrl+end: cursor %d, want 19", ta.CursorPosition)
+	}
+}
+
+func TestPageDownScrollsToCursor(t *testing.T) {
+	ta, h := newArea(t, strings.Repeat("line\n", 9)+"last")
+	ta.SetSelection(0, 0)
+	h.Frame()
+	ta.ScrollToTop()
+
+	h.PressKeys(ebiten.KeyPageDown)
+	if ta.CursorPosition != 15 {
+		t.Fatalf("page down: cursor %d, want 15", ta.CursorPosition)
+	}
+	// The cursor is on line 3, so the view scrolled by one line.
+	if ta.ScrollY != 13 {
+		t.Fatalf("ScrollY = %v, want 13", ta.ScrollY)
+	}
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyEnd)
+	if ta.ScrollY != 13*7 {
+		t.Fatalf("ScrollY = %v at the end, want %v", ta.ScrollY, 13*7)
+	}
+}
+
+func TestMouseWheelAndScrollbar(t *testing.T) {
+	ta, h := newArea(t, strings.Repeat("line\n", 9)+"last")
+	ta.ScrollToTop()
+
+	h.Input.MoveCursor(40, 30)
+	h.Input.Scroll(0, -1)
+	h.Frame()
+	if ta.ScrollY != 3*13 {
+		t.Fatalf("wheel: ScrollY = %v, want %v", ta.ScrollY, 3*13)
+	}
+	h.Input.Scroll(0, -10)
+	h.Frame()
+	if ta.ScrollY != 7*13 {
+		t.Fatalf("wheel past the end: ScrollY = %v, want %v", ta.ScrollY, 7*13)
+	}
+
+	// Drag the thumb from the bottom of the track to the top.
+	h.Press(93, 55)
+	h.MoveCursor(93, 0)
+	h.Release(93, 0)
+	if ta.ScrollY != 0 {
+		t.Fatalf("scrollbar drag: ScrollY = %v, want 0", ta.ScrollY)
+	}
+}
+
+func TestClickPlacesCursorOnLine(t *testing.T) {
+	ta, h := newArea(t, "first\nsecond\nthird")
+
+	h.Click(15+7*3, 15+13+6)
+	if ta.CursorPosition != 9 {
+		t.Fatalf("cursor %d, want 9", ta.CursorPosition)
+	}
+}
+
+func TestCopyPasteAcrossLines(t *testing.T) {
+	var clipboard string
+	restore := textarea.SetClipboard(
+		func(s string) error { clipboard = s; return nil },
+		func() (string, error) { return clipboard, nil },
+	)
+	defer restore()
+
+	ta, h := newArea(t, "ab\ncd")
+	ta.SetSelection(1, 4)
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyX)
+	if clipboard != "b\nc" || ta.Text != "ad" {
+		t.Fatalf("cut: clipboard %q text %q", clipboard, ta.Text)
+	}
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyV)
+	if ta.Text != "ab\ncd" || ta.CursorPosition != 4 {
+		t.Fatalf("paste: text %q cursor %d", ta.Text, ta.CursorPosition)
+	}
+}
+
+func TestMaxLength(t *testing.T) {
+	ta, h := newArea(t, "")
+	ta.MaxLength = 4
+	h.TypeString("ab")
+	h.PressKeys(ebiten.KeyEnter)
+	h.TypeString("cdef")
+	if ta.Text != "ab\nc" {
+		t.Fatalf("got %q", ta.Text)
+	}
+}
+
+func TestHeldArrowRepeats(t *testing.T) {
+	ta, h := newArea(t, "a\nb\nc\nd\ne")
+	ta.SetKeyRepeat(&input.KeyRepeat{Delay: 0.5, Interval: 0.1})
+	ta.SetSelection(0, 0)
+
+	h.Input.PressKey(ebiten.KeyArrowDown)
+	h.Advance(44) // the press plus repeats at about 0.5, 0.6 and 0.7 seconds
+	h.Input.ReleaseKey(ebiten.KeyArrowDown)
+	h.Frame()
+	if ta.CursorPosition != 8 {
+		t.Fatalf("cursor %d, want 8 (start of the fifth line)", ta.CursorPosition)
+	}
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+package textfield
+
+// SetRevertOnCancel sets whether Escape restores the text the field had when
+// it was focused.
+func (tf *TextField) SetRevertOnCancel(revert bool) {
+	tf.RevertOnCancel = revert
+}
+
+// submit validates the text and fires OnSubmit if it is valid.
+func (tf *TextField) submit() {
+	if tf.Revalidate() != nil {
+		return
+	}
+	if tf.OnSubmit != nil {
+		tf.OnSubmit(tf.Text)
+	}
+}
+
+// cancel reverts the text if RevertOnCancel is set, fires OnCancel and leaves the field.
+func (tf *TextField) cancel() {
+	if tf.RevertOnCancel && !tf.ReadOnly && tf.Text != tf.focusText {
+		tf.edit(editAtomic, func() {
+			tf.Text = tf.focusText
+			tf.CursorPosition = tf.length()
+			tf.SelectionAnchor = tf.CursorPosition
+		})
+	}
+	if tf.OnCancel != nil {
+		tf.OnCancel()
+	}
+	tf.Deactivate()
+}
+
+// notifyFocus fires OnFocus or OnBlur if IsActive changed since it last ran,
+// whether through a click, Escape, Activate or Deactivate. Gaining focus
+// records the text that cancelling reverts to.
+func (tf *TextField) notifyFocus() {
+	if tf.IsActive == tf.wasActive {
+		return
+	}
+	tf.wasActive = tf.IsActive
+	if tf.IsActive {
+		tf.focusText = tf.Text
+		if tf.OnFocus != nil {
+			tf.OnFocus()
+		}
+	} else if tf.OnBlur != nil {
+		tf.OnBlur()
+	}
+}
+
+// notifyChange fires OnChange if the text changed since it last ran, whether
+// through editing, SetValue or assigning Text directly.
+func (tf *TextField) notifyChange() {
+	if tf.Text == tf.changedText {
+		return
+	}
+	old := tf.changedText
+	tf.changedText = tf.Text
+	if tf.OnChange != nil {
+		tf.OnChange(old, tf.Text)
+	}
+}
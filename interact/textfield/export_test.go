@@ -0,0 +1,16 @@
+package textfield
+
+// SetClipboard replaces the system clipboard with fake functions for the
+// duration of a test and returns a function restoring the real one.
+func SetClipboard(copyFn func(string) error, pasteFn func() (string, error)) (restore func()) {
+	prevCopy, prevPaste := copyClipboardText, pasteClipboardText
+	copyClipboardText, pasteClipboardText = copyFn, pasteFn
+	return func() {
+		copyClipboardText, pasteClipboardText = prevCopy, prevPaste
+	}
+}
+
+// DisplayText returns the text as the field draws it, with masking applied.
+func (tf *TextField) DisplayText() string {
+	return tf.displayText()
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: MIT
+package textfield
+
+// DefaultHistoryLimit is the number of undo steps a new text field keeps.
+const DefaultHistoryLimit = 100
+
+// editKind groups edits for undo. Consecutive edits of the same coalescing
+// kind share a single undo step; editAtomic edits always get their own.
+type editKind int
+
+const (
+	editAtomic editKind = iota
+	editTyping
+	editDeleting
+)
+
+// snapshot is the state an undo step restores.
+type snapshot struct {
+	text   string
+	cursor int
+	anchor int
+}
+
+func (tf *TextField) snapshot() snapshot {
+	return snapshot{text: tf.Text, cursor: tf.CursorPosition, anchor: tf.SelectionAnchor}
+}
+
+func (tf *TextField) restore(s snapshot) {
+	tf.Text = s.text
+	tf.CursorPosition = s.cursor
+	tf.SelectionAnchor = s.anchor
+	tf.CursorBlinkTimer = 0.0
+}
+
+// edit applies a change to the text and records an undo step for it, unless
+// it continues the previous step of the same kind. Edits that leave the text
+// unchanged are not recorded.
+func (tf *TextField) edit(kind editKind, apply func()) {
+	before := tf.snapshot()
+	apply()
+	if tf.Text == before.text {
+		return
+	}
+	if kind == editAtomic || kind != tf.lastEdit {
+		tf.undoStack = append(tf.undoStack, before)
+		if tf.HistoryLimit > 0 && len(tf.undoStack) > tf.HistoryLimit {
+			tf.undoStack = tf.undoStack[len(tf.undoStack)-tf.HistoryLimit:]
+		}
+	}
+	tf.redoStack = tf.redoStack[:0]
+	tf.lastEdit = kind
+}
+
+// breakUndoGroup makes the next edit start a new undo step.
+func (tf *TextField) breakUndoGroup() {
+	tf.lastEdit = editAtomic
+}
+
+// Undo reverts the last edit and reports whether there was one.
+func (tf *TextField) Undo() bool {
+	if len(tf.undoStack) == 0 {
+		return false
+	}
+	tf.redoStack = append(tf.redoStack, tf.snapshot())
+	tf.restore(tf.undoStack[len(tf.undoStack)-1])
+	tf.undoStack = tf.undoStack[:len(tf.undoStack)-1]
+	tf.breakUndoGroup()
+	return true
+}
+
+// Redo reapplies the last undone edit and reports whether there was one.
+func (tf *TextField) Redo() bool {
+	if len(tf.redoStack) == 0 {
+		return false
+	}
+	tf.undoStack = append(tf.undoStack, tf.snapshot())
+	tf.restore(tf.redoStack[len(tf.redoStack)-1])
+	tf.redoStack = tf.redoStack[:len(tf.redoStack)-1]
+	tf.breakUndoGroup()
+	return true
+}
+
+// CanUndo reports whether there is an edit to undo.
+func (tf *TextField) CanUndo() bool {
+	return len(tf.undoStack) > 0
+}
+
+// CanRedo reports whether there is an undone edit to redo.
+func (tf *TextField) CanRedo() bool {
+	return len(tf.redoStack) > 0
+}
+
+// ClearHistory forgets every undo and redo step.
+func (tf *TextField) ClearHistory() {
+	tf.undoStack = nil
+	tf.redoStack = nil
+	tf.breakUndoGroup()
+}
+
+// SetHistoryLimit sets how many undo steps are kept. Zero or less keeps them all.
+func (tf *TextField) SetHistoryLimit(limit int) {
+	tf.HistoryLimit = limit
+	if limit > 0 && len(tf.undoStack) > limit {
+		tf.undoStack = tf.undoStack[len(tf.undoStack)-limit:]
+	}
+}
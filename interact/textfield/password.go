@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: MIT
+package textfield
+
+import (
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/button"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/draw"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/textutil"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DefaultMaskRune is drawn in place of each character of a masked field.
+const DefaultMaskRune = '•'
+
+// SetMasked turns password mode on or off. A masked field draws every character
+// as MaskRune and never copies or cuts its text to the clipboard.
+func (tf *TextField) SetMasked(masked bool) {
+	tf.Masked = masked
+	tf.revealTimer = 0
+}
+
+func (tf *TextField) IsMasked() bool {
+	return tf.Masked
+}
+
+// SetMaskRune sets the rune drawn in place of each character of a masked field.
+func (tf *TextField) SetMaskRune(r rune) {
+	tf.MaskRune = r
+}
+
+// SetRevealed shows the text of a masked field in clear while keeping copy and
+// cut disabled. RevealToggle calls this when clicked.
+func (tf *TextField) SetRevealed(revealed bool) {
+	tf.Revealed = revealed
+}
+
+func (tf *TextField) IsRevealed() bool {
+	return tf.Revealed
+}
+
+// SetRevealDuration sets how many seconds the last typed character of a masked
+// field stays readable, as on phone keyboards. Zero masks it right away.
+func (tf *TextField) SetRevealDuration(seconds float32) {
+	tf.RevealDuration = seconds
+}
+
+// masked reports whether the text is currently hidden behind mask runes.
+func (tf *TextField) masked() bool {
+	return tf.Masked && !tf.Revealed
+}
+
+// revealLastTyped shows the character before the cursor for RevealDuration.
+func (tf *TextField) revealLastTyped() {
+	if !tf.masked() || tf.RevealDuration <= 0 || tf.CursorPosition == 0 {
+		return
+	}
+	tf.revealPos = tf.CursorPosition - 1
+	tf.revealText = tf.Text
+	tf.revealTimer = tf.RevealDuration
+}
+
+// displayText returns the text as drawn: Text itself, or one mask rune per
+// grapheme cluster when masked. A briefly revealed character is kept as long as
+// the text has not changed since it was typed.
+func (tf *TextField) displayText() string {
+	if !tf.masked() {
+		return tf.Text
+	}
+	mask := tf.MaskRune
+	if mask == 0 {
+		mask = DefaultMaskRune
+	}
+	reveal := -1
+	if tf.revealTimer > 0 && tf.revealText == tf.Text {
+		reveal = tf.revealPos
+	}
+
+	var sb strings.Builder
+	bounds := textutil.Boundaries(tf.Text)
+	for i := 0; i+1 < len(bounds); i++ {
+		if i == reveal {
+			sb.WriteString(tf.Text[bounds[i]:bounds[i+1]])
+		} else {
+			sb.WriteRune(mask)
+		}
+	}
+	return sb.String()
+}
+
+// RevealToggle is a "show password" button for a masked TextField. Each click
+// flips the field's Revealed state. Without a label it draws an eye, crossed out
+// while the password is shown.
+type RevealToggle struct {
+	*button.Button
+	Field *TextField
+}
+
+// NewRevealToggle creates a toggle for field, usually placed at its right edge.
+func NewRevealToggle(field *TextField, x, y, width, height float32) *RevealToggle {
+	return &RevealToggle{
+		Button: button.NewButton(x, y, width, height, ""),
+		Field:  field,
+	}
+}
+
+// Update should be called every frame.
+func (t *RevealToggle) Update() {
+	t.Button.Update()
+	if t.IsClicked() {
+		t.Field.SetRevealed(!t.Field.Revealed)
+	}
+}
+
+// Draw draws the button and, if it has no label, the eye icon.
+func (t *RevealToggle) Draw(screen *ebiten.Image) {
+	t.Button.Draw(screen)
+	if t.Invisible || t.Label != "" {
+		return
+	}
+	drawEye(screen, t.Bounds.X+t.Bounds.W/2, t.Bounds.Y+t.Bounds.H/2, min(t.Bounds.W, t.Bounds.H)*0.7, t.Field.Revealed, t.TextColor)
+}
+
+// drawEye draws an almond shaped eye of the given width centred on (cx, cy),
+// with a slash through it if crossed is set.
+func drawEye(screen *ebiten.Image, cx, cy, width float32, crossed bool, col color.Color) {
+	const segments = 8
+	const stroke = 1.5
+	halfW, halfH := width/2, width/4
+
+	// Upper and lower lids are parabolas meeting at the corners of the eye.
+	prevX, prevUpper, prevLower := cx-halfW, cy, cy
+	for i := 1; i <= segments; i++ {
+		u := float32(i)/segments*2 - 1
+		x := cx + u*halfW
+		lift := halfH * (1 - u*u)
+		draw.Line(screen, prevX, prevUpper, x, cy-lift, stroke, col)
+		draw.Line(screen, prevX, prevLower, x, cy+lift, stroke, col)
+		prevX, prevUpper, prevLower = x, cy-lift, cy+lift
+	}
+	draw.StrokeCircle(screen, cx, cy, halfH*0.7, stroke, col)
+	draw.FillCircle(screen, cx, cy, halfH*0.3, col)
+
+	if crossed {
+		d := halfW * float32(math.Sqrt2) / 2
+		draw.Line(screen, cx-d, cy+d, cx+d, cy-d, stroke, col)
+	}
+}
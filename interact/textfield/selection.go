@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: MIT
+package textfield
+
+import (
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/textutil"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/overlay"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	// Clicks closer together than this (in seconds and pixels) count as a double or triple click.
+	multiClickTime     = 0.4
+	multiClickDistance = 4
+)
+
+// Selection returns the selected range in order. Both ends are equal when nothing is selected.
+func (tf *TextField) Selection() (start, end int) {
+	if tf.SelectionAnchor < tf.CursorPosition {
+		return tf.SelectionAnchor, tf.CursorPosition
+	}
+	return tf.CursorPosition, tf.SelectionAnchor
+}
+
+// HasSelection reports whether any text is selected.
+func (tf *TextField) HasSelection() bool {
+	return tf.SelectionAnchor != tf.CursorPosition
+}
+
+// SelectedText returns the currently selected text.
+func (tf *TextField) SelectedText() string {
+	start, end := tf.Selection()
+	return tf.Text[tf.offset(start):tf.offset(end)]
+}
+
+// SetSelection selects the text between anchor and cursor, leaving the cursor at cursor.
+func (tf *TextField) SetSelection(anchor, cursor int) {
+	tf.SelectionAnchor = clamp(anchor, 0, tf.length())
+	tf.CursorPosition = clamp(cursor, 0, tf.length())
+}
+
+// SelectAll selects the whole text with the cursor at the end.
+func (tf *TextField) SelectAll() {
+	tf.SetSelection(0, tf.length())
+}
+
+// ClearSelection collapses the selection onto the cursor.
+func (tf *TextField) ClearSelection() {
+	tf.SelectionAnchor = tf.CursorPosition
+}
+
+// moveCursor moves the cursor to pos, extending the selection if extend is set
+// and collapsing it otherwise.
+func (tf *TextField) moveCursor(pos int, extend bool) {
+	tf.CursorPosition = pos
+	if !extend {
+		tf.SelectionAnchor = pos
+	}
+	tf.CursorBlinkTimer = 0.0
+	tf.revealTimer = 0.0
+	tf.breakUndoGroup()
+}
+
+// deleteSelection removes the selected text and reports whether there was any.
+func (tf *TextField) deleteSelection() bool {
+	if !tf.HasSelection() {
+		return false
+	}
+	start, end := tf.Selection()
+	tf.Text = tf.Text[:tf.offset(start)] + tf.Text[tf.offset(end):]
+	tf.CursorPosition = start
+	tf.SelectionAnchor = start
+	return true
+}
+
+// clampSelection keeps the cursor and anchor inside the text, in case either
+// was set directly.
+func (tf *TextField) clampSelection() {
+	n := tf.length()
+	tf.CursorPosition = clamp(tf.CursorPosition, 0, n)
+	tf.SelectionAnchor = clamp(tf.SelectionAnchor, 0, n)
+}
+
+// handleMouse activates the field on click, places the cursor, selects a word on
+// double click and everything on triple click, and extends the selection while dragging.
+func (tf *TextField) handleMouse(in input.Input) {
+	mx, my := in.CursorPosition()
+
+	if in.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if !pointInRect(float32(mx), float32(my), tf.Bounds) || overlay.Blocks(tf, float32(mx), float32(my)) {
+			tf.IsActive = false
+			tf.dragging = false
+			return
+		}
+		tf.IsActive = true
+
+		if tf.clickTimer <= multiClickTime && abs(mx-tf.lastClickX) <= multiClickDistance && tf.clickCount < 3 {
+			tf.clickCount++
+		} else {
+			tf.clickCount = 1
+		}
+		tf.clickTimer = 0.0
+		tf.lastClickX = mx
+		tf.breakUndoGroup()
+
+		pos := tf.indexAt(float32(mx))
+		switch tf.clickCount {
+		case 1:
+			tf.moveCursor(pos, in.IsKeyPressed(ebiten.KeyShift))
+			tf.dragging = true
+		case 2:
+			// A masked field has no visible words, so it selects everything.
+			if tf.masked() {
+				tf.SelectAll()
+				tf.dragging = false
+				break
+			}
+			start, end := textutil.WordAt(tf.Text, tf.offset(pos))
+			tf.SetSelection(textutil.Index(tf.Text, start), textutil.Index(tf.Text, end))
+			tf.dragging = false
+		default:
+			tf.SelectAll()
+			tf.dragging = false
+		}
+		return
+	}
+
+	if tf.dragging {
+		if in.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			tf.CursorPosition = tf.indexAt(float32(mx))
+		} else {
+			tf.dragging = false
+		}
+	}
+}
+
+// indexAt returns the grapheme boundary closest to the screen x coordinate,
+// measured with the font's glyph advances from the padded, scrolled text start.
+// Points left or right of the visible text map to positions scrolled out of view.
+func (tf *TextField) indexAt(x float32) int {
+	if tf.FontFace == nil {
+		return tf.length()
+	}
+	return textutil.Nearest(textutil.CaretPositions(tf.FontFace, tf.displayText()), x-tf.textX())
+}
+
+// wordLeft returns the position of the start of the word before the cursor.
+// A masked field is a single word, so its structure is not given away.
+func (tf *TextField) wordLeft() int {
+	if tf.masked() {
+		return 0
+	}
+	return textutil.Index(tf.Text, textutil.PrevWordStart(tf.Text, tf.offset(tf.CursorPosition)))
+}
+
+// wordRight returns the position of the end of the word after the cursor.
+func (tf *TextField) wordRight() int {
+	if tf.masked() {
+		return tf.length()
+	}
+	return textutil.Index(tf.Text, textutil.NextWordEnd(tf.Text, tf.offset(tf.CursorPosition)))
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: MIT
+package textfield
+
+import "github.com/OrtheSnowJames/ebiten-interactive/interact/colorscheme"
+
+// SetStyle sets the field's drop shadow, background gradients, highlight and
+// skins. Fields use the Normal, Focused and Disabled states; states without a
+// gradient keep BackgroundColor. Invalid text is tinted InvalidBackgroundColor,
+// or outlined in InvalidBorderColor over a skin.
+func (tf *TextField) SetStyle(style colorscheme.Style) {
+	tf.Style = style
+}
+
+// state returns the state the field is drawn in.
+func (tf *TextField) state() colorscheme.State {
+	switch {
+	case tf.Uneditable:
+		return colorscheme.Disabled
+	case tf.IsActive:
+		return colorscheme.Focused
+	default:
+		return colorscheme.Normal
+	}
+}
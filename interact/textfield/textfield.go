@@ -3,12 +3,17 @@ package textfield
 
 import (
 	"fmt"
+	"image"
 	"image/color"
 
 	"github.com/OrtheSnowJames/ebiten-interactive/interact/clip"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/clock"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/colorscheme"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/draw"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/paint"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/textutil"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"golang.org/x/image/font"
 )
@@ -22,53 +27,122 @@ func NewRect(x, y, w, h float32) Rect {
 	return Rect{x, y, w, h}
 }
 
-func pasteClipboardText() (string, error) {
-	return clip.PasteClip()
-}
+// Clipboard access goes through these variables so tests can replace it.
+var (
+	copyClipboardText  = clip.CopyClip
+	pasteClipboardText = clip.PasteClip
+)
 
 func pointInRect(x, y float32, r Rect) bool {
 	return x >= r.X && x <= r.X+r.W && y >= r.Y && y <= r.Y+r.H
 }
 
+// textPadding is the horizontal space between the border and the text.
+const textPadding = float32(5)
+
 // DefaultFont is a package-level font face used for drawing text.
 // Set this to a valid font.Face during initialization.
 var DefaultFont font.Face
 
 type TextField struct {
-	Bounds             Rect
-	Text               string
-	MaxLength          int
-	BackgroundColor    color.RGBA
-	BorderColor        color.RGBA
-	TextColor          color.RGBA
-	FontSize           int32
-	FontFace           font.Face
-	IsActive           bool
-	CursorPosition     int
-	CursorBlinkTimer   float32
-	BackspaceHoldTimer float32
-	Invisible          bool
-	Uneditable         bool
-	Placeholder        string
+	Bounds                 Rect
+	Text                   string
+	MaxLength              int // Maximum length in user-perceived characters (grapheme clusters)
+	BackgroundColor        color.RGBA
+	BorderColor            color.RGBA
+	TextColor              color.RGBA
+	FontSize               int32
+	FontFace               font.Face
+	IsActive               bool
+	CursorPosition         int // Caret position, counted in grapheme clusters
+	CursorBlinkTimer       float32
+	BackspaceHoldTimer     float32 // Deprecated: use KeyRepeat. A positive value is the repeat delay while KeyRepeat is nil
+	Invisible              bool
+	Uneditable             bool
+	ReadOnly               bool // Text can be selected and copied but not changed
+	Placeholder            string
+	Input                  input.Input      // Input source; nil uses input.Default()
+	Keymap                 *input.Keymap    // Shortcut modifiers; nil uses input.DefaultKeymap()
+	KeyRepeat              *input.KeyRepeat // Timing of held keys; nil uses input.DefaultKeyRepeat()
+	Clock                  clock.Clock      // Frame timing for blinking and key repeat; nil uses clock.Default()
+	CopyAllIfNoSelect      bool             // Copy and cut act on the whole text when nothing is selected
+	SelectionAnchor        int              // The end of the selection opposite CursorPosition
+	SelectionColor         color.RGBA
+	ScrollX                float32            // How far the text is scrolled left, in pixels
+	HistoryLimit           int                // Maximum number of undo steps; zero or less is unlimited
+	Masked                 bool               // Password mode: draw MaskRune for each character and disable copy and cut
+	MaskRune               rune               // Drawn in place of each character when masked; zero uses DefaultMaskRune
+	Revealed               bool               // Show a masked field's text in clear
+	RevealDuration         float32            // Seconds the last typed character of a masked field stays readable
+	AllowRune              func(rune) bool    // Filters typed and pasted runes; nil allows everything
+	Validate               func(string) error // Checks the text whenever it changes; nil accepts everything
+	InvalidBackgroundColor color.RGBA
+	InvalidBorderColor     color.RGBA
+	ErrorTextColor         color.RGBA
+	ShowErrorMessage       bool              // Draw the validation error below the field
+	RevertOnCancel         bool              // Escape restores the text the field had when it was focused
+	Style                  colorscheme.Style // Shadow, gradients and highlight; the zero Style is flat
+
+	// Event callbacks, all called from Update. Nil callbacks are skipped.
+	OnChange func(old, new string) // The text changed, by editing or otherwise
+	OnSubmit func(text string)     // Enter was pressed and the text is valid
+	OnCancel func()                // Escape was pressed; the field loses focus afterwards
+	OnFocus  func()                // The field became active
+	OnBlur   func()                // The field stopped being active
+
+	undoStack []snapshot
+	redoStack []snapshot
+	lastEdit  editKind
+
+	repeater input.Repeater
+
+	dragging   bool
+	clickCount int
+	clickTimer float32
+	lastClickX int
+
+	revealPos   int
+	revealText  string
+	revealTimer float32
+
+	validatedText string
+	validationErr error
+
+	wasActive   bool
+	focusText   string
+	changedText string
 }
 
 func NewTextField(x, y, width, height float32, maxLength int) *TextField {
 	return &TextField{
-		Bounds:             NewRect(x, y, width, height),
-		Text:               "",
-		MaxLength:          maxLength,
-		BackgroundColor:    color.RGBA{R: 255, G: 255, B: 255, A: 255}, // White
-		BorderColor:        color.RGBA{R: 0, G: 0, B: 0, A: 255},       // Black
-		TextColor:          color.RGBA{R: 0, G: 0, B: 0, A: 255},       // Black
-		FontSize:           20,
-		FontFace:           DefaultFont,
-		IsActive:           false,
-		CursorPosition:     0,
-		CursorBlinkTimer:   0.0,
-		BackspaceHoldTimer: 0.0,
-		Invisible:          false,
-		Uneditable:         false,
-		Placeholder:        "",
+		Bounds:                 NewRect(x, y, width, height),
+		Text:                   "",
+		MaxLength:              maxLength,
+		BackgroundColor:        color.RGBA{R: 255, G: 255, B: 255, A: 255}, // White
+		BorderColor:            color.RGBA{R: 0, G: 0, B: 0, A: 255},       // Black
+		TextColor:              color.RGBA{R: 0, G: 0, B: 0, A: 255},       // Black
+		FontSize:               20,
+		FontFace:               DefaultFont,
+		IsActive:               false,
+		CursorPosition:         0,
+		CursorBlinkTimer:       0.0,
+		BackspaceHoldTimer:     0.0,
+		Invisible:              false,
+		Uneditable:             false,
+		ReadOnly:               false,
+		Placeholder:            "",
+		SelectionAnchor:        0,
+		SelectionColor:         color.RGBA{R: 173, G: 214, B: 255, A: 255}, // Light blue
+		ScrollX:                0.0,
+		HistoryLimit:           DefaultHistoryLimit,
+		Masked:                 false,
+		MaskRune:               DefaultMaskRune,
+		Revealed:               false,
+		RevealDuration:         0.0,
+		InvalidBackgroundColor: color.RGBA{R: 255, G: 235, B: 235, A: 255}, // Pale red
+		InvalidBorderColor:     color.RGBA{R: 200, G: 0, B: 0, A: 255},     // Dark red
+		ErrorTextColor:         color.RGBA{R: 200, G: 0, B: 0, A: 255},     // Dark red
+		ShowErrorMessage:       true,
 	}
 }
 
@@ -98,6 +172,51 @@ func (tf *TextField) IsUneditable() bool {
 	return tf.Uneditable
 }
 
+// SetReadOnly makes the text selectable and copyable but not editable.
+// Unlike SetUneditable the field still reacts to the mouse and keyboard.
+func (tf *TextField) SetReadOnly(readOnly bool) {
+	tf.ReadOnly = readOnly
+}
+
+func (tf *TextField) IsReadOnly() bool {
+	return tf.ReadOnly
+}
+
+// SetInput sets the input source the text field reads from. Passing nil uses input.Default().
+func (tf *TextField) SetInput(in input.Input) {
+	tf.Input = in
+}
+
+// SetKeymap sets the modifier keys used for shortcuts. Passing nil uses input.DefaultKeymap().
+func (tf *TextField) SetKeymap(keymap *input.Keymap) {
+	tf.Keymap = keymap
+}
+
+// SetKeyRepeat sets how held keys repeat. Passing nil uses input.DefaultKeyRepeat().
+func (tf *TextField) SetKeyRepeat(repeat *input.KeyRepeat) {
+	tf.KeyRepeat = repeat
+}
+
+// keyRepeat returns the timing of held keys, taking the deprecated
+// BackspaceHoldTimer as the delay.
+func (tf *TextField) keyRepeat() input.KeyRepeat {
+	timing := input.KeyRepeatOr(tf.KeyRepeat)
+	if tf.KeyRepeat == nil && tf.BackspaceHoldTimer > 0 {
+		timing.Delay = tf.BackspaceHoldTimer
+	}
+	return timing
+}
+
+// SetClock sets the clock timers and key repeat are measured with. Passing nil uses clock.Default().
+func (tf *TextField) SetClock(c clock.Clock) {
+	tf.Clock = c
+}
+
+// SetCopyAllIfNoSelection makes copy and cut act on the whole text when nothing is selected.
+func (tf *TextField) SetCopyAllIfNoSelection(copyAll bool) {
+	tf.CopyAllIfNoSelect = copyAll
+}
+
 // Update should be called every frame.
 func (tf *TextField) Update() {
 	// If uneditable than this is useless
@@ -105,98 +224,246 @@ func (tf *TextField) Update() {
 		return
 	}
 
-	// Update cursor blink timer (assuming 60 FPS)
-	tf.CursorBlinkTimer += 1.0 / 60.0
+	// Update cursor blink timer.
+	dt := clock.Or(tf.Clock).Delta()
+	tf.CursorBlinkTimer += dt
 	if tf.CursorBlinkTimer >= 1.0 {
-		tf.CursorBlinkTimer = 0.0
+		tf.CursorBlinkTimer -= 1.0
 	}
 
-	// Handle mouse click to activate/deactivate the text field.
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		mx, my := ebiten.CursorPosition()
-		if pointInRect(float32(mx), float32(my), tf.Bounds) {
-			tf.IsActive = true
-		} else {
-			tf.IsActive = false
-		}
-	}
+	in := input.Or(tf.Input)
+	tf.clickTimer += dt
+	tf.revealTimer = max(0, tf.revealTimer-dt)
+	tf.clampSelection()
+
+	tf.handleMouse(in)
+	tf.revalidateIfChanged()
+	tf.notifyFocus()
 
 	if tf.IsActive {
-		// Append typed characters.
-		for _, ch := range ebiten.InputChars() {
-			if len(tf.Text) < tf.MaxLength {
-				tf.Text = tf.Text[:tf.CursorPosition] + string(ch) + tf.Text[tf.CursorPosition:]
-				tf.CursorPosition++
+		shift := in.IsKeyPressed(ebiten.KeyShift)
+		keymap := input.KeymapOr(tf.Keymap)
+		word := in.IsKeyPressed(keymap.Word)
+		editable := !tf.ReadOnly
+
+		// Append typed characters, replacing the selection.
+		// Replacing a selection is its own undo step; plain typing is coalesced.
+		if chars := in.AppendInputChars(nil); len(chars) > 0 && editable {
+			kind := editTyping
+			if tf.HasSelection() {
+				kind = editAtomic
 			}
+			tf.edit(kind, func() {
+				tf.deleteSelection()
+				for _, ch := range chars {
+					if tf.allowed(ch) {
+						tf.insert(string(ch))
+					}
+				}
+			})
+			tf.revealLastTyped()
 		}
 
-		// Handle backspace (single press).
-		if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
-			if tf.CursorPosition > 0 {
-				tf.Text = tf.Text[:tf.CursorPosition-1] + tf.Text[tf.CursorPosition:]
-				tf.CursorPosition--
+		// Editing and movement keys repeat while held.
+		tf.repeater.Update(in, tf.keyRepeat(), dt)
+
+		// Handle backspace and delete, by word with the word modifier.
+		if editable {
+			for range tf.repeater.Presses(in, ebiten.KeyBackspace) {
+				tf.backspace(word)
+			}
+			for range tf.repeater.Presses(in, ebiten.KeyDelete) {
+				tf.deleteForward(word)
 			}
 		}
 
-		// Handle left arrow.
-		if inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) && tf.CursorPosition > 0 {
-			tf.CursorPosition--
+		// Handle left arrow. Without shift a selection collapses to its start.
+		for range tf.repeater.Presses(in, ebiten.KeyArrowLeft) {
+			if word {
+				tf.moveCursor(tf.wordLeft(), shift)
+			} else if start, _ := tf.Selection(); tf.HasSelection() && !shift {
+				tf.moveCursor(start, false)
+			} else if tf.CursorPosition > 0 {
+				tf.moveCursor(tf.CursorPosition-1, shift)
+			}
 		}
 
-		// Handle right arrow.
-		if inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) && tf.CursorPosition < len(tf.Text) {
-			tf.CursorPosition++
+		// Handle right arrow. Without shift a selection collapses to its end.
+		for range tf.repeater.Presses(in, ebiten.KeyArrowRight) {
+			if word {
+				tf.moveCursor(tf.wordRight(), shift)
+			} else if _, end := tf.Selection(); tf.HasSelection() && !shift {
+				tf.moveCursor(end, false)
+			} else if tf.CursorPosition < tf.length() {
+				tf.moveCursor(tf.CursorPosition+1, shift)
+			}
 		}
 
 		// Handle home key.
-		if inpututil.IsKeyJustPressed(ebiten.KeyHome) {
-			tf.CursorPosition = 0
+		if in.IsKeyJustPressed(ebiten.KeyHome) {
+			tf.moveCursor(0, shift)
 		}
 
 		// Handle end key.
-		if inpututil.IsKeyJustPressed(ebiten.KeyEnd) {
-			tf.CursorPosition = len(tf.Text)
+		if in.IsKeyJustPressed(ebiten.KeyEnd) {
+			tf.moveCursor(tf.length(), shift)
 		}
 
-		// Handle continuous backspace hold.
-		if ebiten.IsKeyPressed(ebiten.KeyBackspace) {
-			tf.BackspaceHoldTimer += 1.0 / 60.0
-			if tf.BackspaceHoldTimer > 0.5 {
-				tf.BackspaceHoldTimer = 1.0
-				if tf.CursorPosition > 0 {
-					tf.Text = tf.Text[:tf.CursorPosition-1] + tf.Text[tf.CursorPosition:]
-					tf.CursorPosition--
-				}
-			}
-		} else {
-			tf.BackspaceHoldTimer = 0.0
+		// Handle Control+A (select all).
+		if keymap.IsShortcut(in, ebiten.KeyA) {
+			tf.SelectAll()
 		}
 
-		// Handle Control+A (select all).
-		if inpututil.IsKeyJustPressed(ebiten.KeyA) && ebiten.IsKeyPressed(ebiten.KeyControl) {
-			tf.CursorPosition = len(tf.Text)
+		// Handle Control+C (copy).
+		if keymap.IsShortcut(in, ebiten.KeyC) {
+			tf.copySelection()
 		}
 
-		// Handle Control+V (paste).
-		if inpututil.IsKeyJustPressed(ebiten.KeyV) && ebiten.IsKeyPressed(ebiten.KeyControl) {
-			clipboardText, err := pasteClipboardText()
-			if err != nil {
-				panic(fmt.Errorf("failed to paste text from clipboard (from ebiten-interactive textfield): %w", err))
-			}
-		
-			if clipboardText != "" {
-				remainingSpace := tf.MaxLength - len(tf.Text)
-				if remainingSpace > 0 {
-					toPaste := clipboardText
-					if len(toPaste) > remainingSpace {
-						toPaste = toPaste[:remainingSpace]
+		// Handle Control+X (cut). Read-only fields copy instead.
+		if keymap.IsShortcut(in, ebiten.KeyX) {
+			if tf.copySelection() && editable {
+				tf.edit(editAtomic, func() {
+					if !tf.HasSelection() {
+						tf.SelectAll()
 					}
-					tf.Text = tf.Text[:tf.CursorPosition] + toPaste + tf.Text[tf.CursorPosition:]
-					tf.CursorPosition += len(toPaste)
+					tf.deleteSelection()
+				})
+			}
+		}
+
+		if editable {
+			// Handle Control+V (paste), replacing the selection.
+			for range tf.repeater.Shortcut(in, keymap, ebiten.KeyV) {
+				tf.paste()
+			}
+
+			// Handle Control+Z (undo), Control+Shift+Z and Control+Y (redo).
+			for range tf.repeater.Shortcut(in, keymap, ebiten.KeyZ) {
+				if shift {
+					tf.Redo()
+				} else {
+					tf.Undo()
 				}
 			}
+			for range tf.repeater.Shortcut(in, keymap, ebiten.KeyY) {
+				tf.Redo()
+			}
+		}
+
+		// Handle Enter (submit) and Escape (cancel).
+		if in.IsKeyJustPressed(ebiten.KeyEnter) || in.IsKeyJustPressed(ebiten.KeyNumpadEnter) {
+			tf.submit()
+		}
+		if in.IsKeyJustPressed(ebiten.KeyEscape) {
+			tf.cancel()
+		}
+
+		tf.ensureCursorVisible()
+	}
+
+	tf.revalidateIfChanged()
+	tf.notifyChange()
+	tf.notifyFocus()
+}
+
+// length returns the length of the text in grapheme clusters.
+func (tf *TextField) length() int {
+	return textutil.GraphemeCount(tf.Text)
+}
+
+// offset returns the byte offset in Text of the grapheme position pos.
+func (tf *TextField) offset(pos int) int {
+	return textutil.Offset(tf.Text, pos)
+}
+
+// insert inserts s at the cursor and moves the cursor after it, unless the
+// result would be longer than MaxLength. Combining marks and joiners may merge
+// with the text around them, so the new cursor position is measured rather
+// than added up.
+func (tf *TextField) insert(s string) {
+	at := tf.offset(tf.CursorPosition)
+	result := tf.Text[:at] + s + tf.Text[at:]
+	if textutil.GraphemeCount(result) > tf.MaxLength {
+		return
+	}
+	tf.Text = result
+	tf.CursorPosition = textutil.Index(tf.Text, at+len(s))
+	tf.SelectionAnchor = tf.CursorPosition
+}
+
+// paste inserts the clipboard text in place of the selection, dropping runes
+// AllowRune rejects and anything past MaxLength.
+func (tf *TextField) paste() {
+	clipboardText, err := pasteClipboardText()
+	if err != nil {
+		panic(fmt.Errorf("failed to paste text from clipboard (from ebiten-interactive textfield): %w", err))
+	}
+
+	if clipboardText = tf.filter(clipboardText); clipboardText != "" {
+		tf.edit(editAtomic, func() {
+			tf.deleteSelection()
+			remainingSpace := tf.MaxLength - tf.length()
+			if remainingSpace > 0 {
+				tf.insert(textutil.Truncate(clipboardText, remainingSpace))
+			}
+		})
+	}
+}
+
+// copySelection copies the selected text, or the whole text when nothing is
+// selected and CopyAllIfNoSelect is set. It reports whether anything was copied.
+// Masked fields never copy, even while revealed.
+func (tf *TextField) copySelection() bool {
+	if tf.Masked {
+		return false
+	}
+	toCopy := tf.SelectedText()
+	if !tf.HasSelection() {
+		if !tf.CopyAllIfNoSelect || tf.Text == "" {
+			return false
 		}
-		
+		toCopy = tf.Text
+	}
+	if err := copyClipboardText(toCopy); err != nil {
+		panic(fmt.Errorf("failed to copy text to clipboard (from ebiten-interactive textfield): %w", err))
+	}
+	return true
+}
+
+// backspace deletes the selection, or the character or word before the cursor
+// if nothing is selected. Deleting a selection is its own undo step; repeated
+// single deletions are coalesced.
+func (tf *TextField) backspace(word bool) {
+	start := tf.CursorPosition - 1
+	if word {
+		start = tf.wordLeft()
+	}
+	tf.deleteRange(max(start, 0), tf.CursorPosition)
+}
+
+// deleteForward deletes the selection, or the character or word after the
+// cursor if nothing is selected.
+func (tf *TextField) deleteForward(word bool) {
+	end := tf.CursorPosition + 1
+	if word {
+		end = tf.wordRight()
+	}
+	tf.deleteRange(tf.CursorPosition, min(end, tf.length()))
+}
+
+// deleteRange deletes the selection if there is one and the positions from
+// start to end otherwise, leaving the cursor at start.
+func (tf *TextField) deleteRange(start, end int) {
+	if tf.HasSelection() {
+		tf.edit(editAtomic, func() { tf.deleteSelection() })
+		return
+	}
+	if start < end {
+		tf.edit(editDeleting, func() {
+			tf.Text = tf.Text[:tf.offset(start)] + tf.Text[tf.offset(end):]
+			tf.CursorPosition = start
+			tf.SelectionAnchor = start
+		})
 	}
 }
 
@@ -206,73 +473,131 @@ func (tf *TextField) Draw(screen *ebiten.Image) {
 		return
 	}
 
-	// Draw background.
-	ebitenutil.DrawRect(screen, float64(tf.Bounds.X), float64(tf.Bounds.Y), float64(tf.Bounds.W), float64(tf.Bounds.H), tf.BackgroundColor)
-
-	// Border color: red if active.
-	drawBorderColor := tf.BorderColor
-	if tf.IsActive {
-		drawBorderColor = color.RGBA{R: 255, G: 0, B: 0, A: 255} // Red
+	// Draw the shadow and background, tinted while the text is invalid.
+	x, y, w, h := tf.Bounds.X, tf.Bounds.Y, tf.Bounds.W, tf.Bounds.H
+	paint.Shadow(screen, x, y, w, h, draw.Radii{}, tf.Style)
+	if skin := tf.Style.Skins.For(tf.state()); skin != nil {
+		// Skins draw their own border; invalid text still gets a red one.
+		skin.Draw(screen, x, y, w, h)
+		if !tf.IsValid() {
+			draw.StrokeRect(screen, x, y, w, h, 2, tf.InvalidBorderColor)
+		}
+	} else {
+		background := colorscheme.Flat(tf.InvalidBackgroundColor)
+		if tf.IsValid() {
+			background = paint.Background(tf.Style, tf.state(), tf.BackgroundColor)
+		}
+		draw.FillRoundedRectGradient(screen, x, y, w, h, draw.Radii{}, paint.Gradient(background))
+		paint.Highlight(screen, x, y, w, h, draw.Radii{}, 2, tf.Style)
+
+		// Border color: red if active, InvalidBorderColor if invalid.
+		drawBorderColor := tf.BorderColor
+		if !tf.IsValid() {
+			drawBorderColor = tf.InvalidBorderColor
+		} else if tf.IsActive {
+			drawBorderColor = color.RGBA{R: 255, G: 0, B: 0, A: 255} // Red
+		}
+		draw.StrokeRect(screen, x, y, w, h, 2, drawBorderColor)
 	}
-	drawRectOutline(screen, tf.Bounds, 2.0, drawBorderColor)
 
-	// Draw text with a small padding.
-	padding := float32(5)
 	if tf.FontFace == nil {
 		return
 	}
 	textY := tf.Bounds.Y + (tf.Bounds.H-float32(tf.FontSize))/2.0
 
+	// The error message sits just below the field, outside the clipped content.
+	if err := tf.ValidationError(); err != nil && tf.ShowErrorMessage {
+		text.Draw(screen, err.Error(), tf.FontFace, int(tf.Bounds.X), int(tf.Bounds.Y+tf.Bounds.H)+int(tf.FontSize), tf.ErrorTextColor)
+	}
+
+	// Text is clipped to the bounds and shifted left by the horizontal scroll.
+	// The clip is inset by a pixel so scrolled text never covers the border.
+	clipRect := image.Rect(int(tf.Bounds.X)+1, int(tf.Bounds.Y)+1, int(tf.Bounds.X+tf.Bounds.W), int(tf.Bounds.Y+tf.Bounds.H))
+	content := screen.SubImage(clipRect).(*ebiten.Image)
+	textX := tf.textX()
+	displayText := tf.displayText()
+
+	// Highlight the selection behind the text.
+	if tf.HasSelection() {
+		start, end := tf.Selection()
+		startX := textX + caretX(tf.FontFace, displayText, start)
+		endX := textX + caretX(tf.FontFace, displayText, end)
+		draw.FillRect(content, startX, textY, endX-startX, float32(tf.FontSize), tf.SelectionColor)
+	}
+
 	// Draw either the text or placeholder
-	displayText := tf.Text
+	drawnText := displayText
 	textColor := tf.TextColor
-	if displayText == "" && tf.Placeholder != "" {
-		displayText = tf.Placeholder
+	if drawnText == "" && tf.Placeholder != "" {
+		drawnText = tf.Placeholder
 		textColor = color.RGBA{R: 128, G: 128, B: 128, A: 255} // Gray color for placeholder
 	}
-	text.Draw(screen, displayText, tf.FontFace, int(tf.Bounds.X)+int(padding), int(textY)+int(tf.FontSize), textColor)
+	text.Draw(content, drawnText, tf.FontFace, int(textX), int(textY)+int(tf.FontSize), textColor)
 
 	// Draw the cursor if active and during the blink phase.
 	if tf.IsActive && tf.CursorBlinkTimer < 0.5 {
-		subText := tf.Text[:tf.CursorPosition]
-		bounds := text.BoundString(tf.FontFace, subText)
-		textWidth := float32(bounds.Dx())
-		cursorX := tf.Bounds.X + padding + textWidth
-		ebitenutil.DrawLine(screen, float64(cursorX), float64(textY), float64(cursorX), float64(textY+float32(tf.FontSize)), tf.TextColor)
+		cursorX := textX + caretX(tf.FontFace, displayText, tf.CursorPosition)
+		draw.Line(content, cursorX, textY, cursorX, textY+float32(tf.FontSize), 1, tf.TextColor)
+	}
+}
+
+// textX returns the screen x where the text starts, taking the padding and
+// horizontal scroll into account.
+func (tf *TextField) textX() float32 {
+	return tf.Bounds.X + textPadding - tf.ScrollX
+}
+
+// ensureCursorVisible scrolls the text horizontally just enough to keep the
+// cursor inside the field, and scrolls back when the text gets shorter.
+func (tf *TextField) ensureCursorVisible() {
+	if tf.FontFace == nil {
+		tf.ScrollX = 0
+		return
 	}
+	// Leave a pixel for the caret itself at the right edge.
+	viewWidth := tf.Bounds.W - 2*textPadding - 1
+	displayText := tf.displayText()
+	cursorX := caretX(tf.FontFace, displayText, tf.CursorPosition)
+	if cursorX-tf.ScrollX < 0 {
+		tf.ScrollX = cursorX
+	} else if cursorX-tf.ScrollX > viewWidth {
+		tf.ScrollX = cursorX - viewWidth
+	}
+	maxScroll := max(0, textutil.Width(tf.FontFace, displayText)-viewWidth)
+	tf.ScrollX = max(0, min(tf.ScrollX, maxScroll))
 }
 
-func drawRectOutline(screen *ebiten.Image, r Rect, thickness float32, col color.RGBA) {
-	// Top line.
-	ebitenutil.DrawLine(screen, float64(r.X), float64(r.Y), float64(r.X+r.W), float64(r.Y), col)
-	// Bottom line.
-	ebitenutil.DrawLine(screen, float64(r.X), float64(r.Y+r.H), float64(r.X+r.W), float64(r.Y+r.H), col)
-	// Left line.
-	ebitenutil.DrawLine(screen, float64(r.X), float64(r.Y), float64(r.X), float64(r.Y+r.H), col)
-	// Right line.
-	ebitenutil.DrawLine(screen, float64(r.X+r.W), float64(r.Y), float64(r.X+r.W), float64(r.Y+r.H), col)
+// caretX returns the x offset of grapheme position pos within the drawn text s.
+func caretX(face font.Face, s string, pos int) float32 {
+	return textutil.Width(face, s[:textutil.Offset(s, pos)])
 }
 
 func (tf *TextField) GetText() string {
 	return tf.Text
 }
 
+// SetValue replaces the text, as when loading a saved value into the field,
+// and places the cursor at the end. It clears the undo history, so Undo
+// cannot go back past the loaded value to text from before it.
 func (tf *TextField) SetValue(value string) {
-	if len(value) > tf.MaxLength {
-		tf.Text = value[:tf.MaxLength]
-	} else {
-		tf.Text = value
-	}
-	tf.CursorPosition = len(tf.Text)
+	tf.Text = textutil.Truncate(value, tf.MaxLength)
+	tf.CursorPosition = tf.length()
+	tf.SelectionAnchor = tf.CursorPosition
+	tf.ClearHistory()
+	tf.ensureCursorVisible()
+	tf.Revalidate()
 }
 
 func (tf *TextField) Activate() {
 	tf.IsActive = true
-	tf.CursorPosition = len(tf.Text)
+	tf.CursorPosition = tf.length()
+	tf.SelectionAnchor = tf.CursorPosition
+	tf.ensureCursorVisible()
 }
 
 func (tf *TextField) Deactivate() {
 	tf.IsActive = false
+	tf.dragging = false
 }
 
 // Add a method to set the placeholder text
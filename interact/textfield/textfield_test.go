@@ -0,0 +1,966 @@
+package textfield_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/clock"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/input"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/testutil"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/textfield"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/validate"
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+)
+
+func newField(t *testing.T, maxLength int) (*textfield.TextField, *testutil.Harness) {
+	t.Helper()
+	tf := textfield.NewTextField(10, 10, 200, 30, maxLength)
+	// Use Control shortcuts regardless of the platform running the tests.
+	keymap := input.PCKeymap()
+	tf.SetKeymap(&keymap)
+	h := testutil.NewHarness(tf)
+	t.Cleanup(h.Close)
+	return tf, h
+}
+
+func TestClickActivates(t *testing.T) {
+	tf, h := newField(t, 20)
+
+	h.Click(50, 20)
+	if !tf.IsActive {
+		t.Fatal("field not active after clicking inside it")
+	}
+	h.Click(300, 300)
+	if tf.IsActive {
+		t.Fatal("field still active after clicking outside it")
+	}
+}
+
+func TestTyping(t *testing.T) {
+	tf, h := newField(t, 20)
+
+	h.TypeString("ignored")
+	if tf.Text != "" {
+		t.Fatalf("inactive field accepted input: %q", tf.Text)
+	}
+
+	tf.Activate()
+	h.TypeString("hello")
+	if tf.Text != "hello" || tf.CursorPosition != 5 {
+		t.Fatalf("got %q cursor %d, want %q cursor 5", tf.Text, tf.CursorPosition, "hello")
+	}
+}
+
+func TestMaxLength(t *testing.T) {
+	tf, h := newField(t, 3)
+	tf.Activate()
+
+	h.TypeString("abcdef")
+	if tf.Text != "abc" {
+		t.Fatalf("got %q, want %q", tf.Text, "abc")
+	}
+}
+
+func TestCursorMovementAndBackspace(t *testing.T) {
+	tf, h := newField(t, 20)
+	tf.Activate()
+	h.TypeString("abc")
+
+	h.PressKeys(ebiten.KeyArrowLeft)
+	h.PressKeys(ebiten.KeyBackspace)
+	if tf.Text != "ac" || tf.CursorPosition != 1 {
+		t.Fatalf("got %q cursor %d, want %q cursor 1", tf.Text, tf.CursorPosition, "ac")
+	}
+
+	h.PressKeys(ebiten.KeyHome)
+	h.TypeString("x")
+	if tf.Text != "xac" {
+		t.Fatalf("got %q, want %q", tf.Text, "xac")
+	}
+
+	h.PressKeys(ebiten.KeyEnd)
+	if tf.CursorPosition != 3 {
+		t.Fatalf("cursor %d after End, want 3", tf.CursorPosition)
+	}
+}
+
+// newFieldWithFont returns an active field using a fixed-width 7px font, so
+// the character boundary i sits at x = 15 + 7*i.
+func newFieldWithFont(t *testing.T, value string) (*textfield.TextField, *testutil.Harness) {
+	t.Helper()
+	tf, h := newField(t, 100)
+	tf.SetFont(basicfont.Face7x13)
+	tf.SetValue(value)
+	tf.Activate()
+	return tf, h
+}
+
+func boundaryX(i int) int {
+	return 15 + 7*i
+}
+
+func TestShiftArrowSelection(t *testing.T) {
+	tf, h := newField(t, 20)
+	tf.Activate()
+	h.TypeString("hello")
+
+	h.PressKeys(ebiten.KeyShift, ebiten.KeyArrowLeft)
+	h.PressKeys(ebiten.KeyShift, ebiten.KeyArrowLeft)
+	if got := tf.SelectedText(); got != "lo" {
+		t.Fatalf("selected %q, want %q", got, "lo")
+	}
+
+	h.TypeString("p")
+	if tf.Text != "help" || tf.HasSelection() {
+		t.Fatalf("got %q (selection %v), want %q with no selection", tf.Text, tf.HasSelection(), "help")
+	}
+
+	h.PressKeys(ebiten.KeyShift, ebiten.KeyHome)
+	if got := tf.SelectedText(); got != "help" {
+		t.Fatalf("shift+home selected %q, want %q", got, "help")
+	}
+	h.PressKeys(ebiten.KeyArrowRight)
+	if tf.HasSelection() || tf.CursorPosition != 4 {
+		t.Fatalf("right arrow did not collapse to the end: cursor %d", tf.CursorPosition)
+	}
+}
+
+func TestSelectAllAndBackspace(t *testing.T) {
+	tf, h := newField(t, 20)
+	tf.SetValue("secret")
+	tf.Activate()
+
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyA)
+	if got := tf.SelectedText(); got != "secret" {
+		t.Fatalf("ctrl+a selected %q", got)
+	}
+	h.PressKeys(ebiten.KeyBackspace)
+	if tf.Text != "" {
+		t.Fatalf("backspace left %q", tf.Text)
+	}
+}
+
+func TestMouseDragSelection(t *testing.T) {
+	tf, h := newFieldWithFont(t, "abcdef")
+
+	h.Press(boundaryX(1), 20)
+	h.MoveCursor(boundaryX(4), 20)
+	h.Release(boundaryX(4), 20)
+	if got := tf.SelectedText(); got != "bcd" {
+		t.Fatalf("drag selected %q, want %q", got, "bcd")
+	}
+	if tf.CursorPosition != 4 {
+		t.Fatalf("cursor %d after drag, want 4", tf.CursorPosition)
+	}
+}
+
+func TestDoubleAndTripleClick(t *testing.T) {
+	tf, h := newFieldWithFont(t, "hello world")
+
+	x := boundaryX(8)
+	h.Click(x, 20)
+	h.Click(x, 20)
+	if got := tf.SelectedText(); got != "world" {
+		t.Fatalf("double click selected %q, want %q", got, "world")
+	}
+	h.Click(x, 20)
+	if got := tf.SelectedText(); got != "hello world" {
+		t.Fatalf("triple click selected %q, want everything", got)
+	}
+
+	// A later, separate click just places the cursor.
+	h.Advance(60)
+	h.Click(boundaryX(2), 20)
+	if tf.HasSelection() || tf.CursorPosition != 2 {
+		t.Fatalf("single click: selection %v cursor %d", tf.HasSelection(), tf.CursorPosition)
+	}
+}
+
+// fakeClipboard installs an in-memory clipboard for the test.
+func fakeClipboard(t *testing.T) *string {
+	t.Helper()
+	var contents string
+	restore := textfield.SetClipboard(
+		func(s string) error { contents = s; return nil },
+		func() (string, error) { return contents, nil },
+	)
+	t.Cleanup(restore)
+	return &contents
+}
+
+func TestCopyCutPaste(t *testing.T) {
+	clipboard := fakeClipboard(t)
+	tf, h := newField(t, 20)
+	tf.SetValue("room-1234")
+	tf.Activate()
+
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyC)
+	if *clipboard != "" {
+		t.Fatalf("copy without a selection copied %q", *clipboard)
+	}
+
+	tf.SetSelection(5, 9)
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyX)
+	if *clipboard != "1234" || tf.Text != "room-" {
+		t.Fatalf("cut: clipboard %q text %q", *clipboard, tf.Text)
+	}
+
+	h.PressKeys(ebiten.KeyHome)
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyV)
+	if tf.Text != "1234room-" {
+		t.Fatalf("paste: text %q", tf.Text)
+	}
+}
+
+func TestCopyAllIfNoSelection(t *testing.T) {
+	clipboard := fakeClipboard(t)
+	tf, h := newField(t, 20)
+	tf.SetValue("ABCD")
+	tf.SetCopyAllIfNoSelection(true)
+	tf.Activate()
+
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyC)
+	if *clipboard != "ABCD" || tf.Text != "ABCD" {
+		t.Fatalf("copy: clipboard %q text %q", *clipboard, tf.Text)
+	}
+	*clipboard = ""
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyX)
+	if *clipboard != "ABCD" || tf.Text != "" {
+		t.Fatalf("cut: clipboard %q text %q", *clipboard, tf.Text)
+	}
+}
+
+func TestReadOnlyCopiesButDoesNotEdit(t *testing.T) {
+	clipboard := fakeClipboard(t)
+	*clipboard = "pasted"
+	tf, h := newField(t, 20)
+	tf.SetValue("XK42")
+	tf.SetReadOnly(true)
+	tf.Activate()
+
+	h.TypeString("zz")
+	h.PressKeys(ebiten.KeyBackspace)
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyV)
+	if tf.Text != "XK42" {
+		t.Fatalf("read-only field was edited: %q", tf.Text)
+	}
+
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyA)
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyX)
+	if *clipboard != "XK42" || tf.Text != "XK42" {
+		t.Fatalf("cut on read-only field: clipboard %q text %q", *clipboard, tf.Text)
+	}
+}
+
+func TestMacKeymap(t *testing.T) {
+	clipboard := fakeClipboard(t)
+	tf, h := newField(t, 20)
+	keymap := input.MacKeymap()
+	tf.SetKeymap(&keymap)
+	tf.SetValue("mac")
+	tf.Activate()
+
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyA)
+	if tf.HasSelection() {
+		t.Fatal("ctrl+a selected text with the mac keymap")
+	}
+	h.PressKeys(ebiten.KeyMetaLeft, ebiten.KeyA)
+	h.PressKeys(ebiten.KeyMetaLeft, ebiten.KeyC)
+	if *clipboard != "mac" {
+		t.Fatalf("cmd+c copied %q", *clipboard)
+	}
+}
+
+func TestUndoCoalescesTyping(t *testing.T) {
+	tf, h := newField(t, 50)
+	tf.Activate()
+
+	h.TypeString("hello")
+	h.PressKeys(ebiten.KeyArrowLeft) // moving the cursor starts a new step
+	h.TypeString("XY")
+	if tf.Text != "hellXYo" {
+		t.Fatalf("got %q", tf.Text)
+	}
+
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyZ)
+	if tf.Text != "hello" {
+		t.Fatalf("first undo: got %q, want %q", tf.Text, "hello")
+	}
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyZ)
+	if tf.Text != "" {
+		t.Fatalf("second undo: got %q, want empty", tf.Text)
+	}
+	if tf.CanUndo() {
+		t.Fatal("history should be empty")
+	}
+
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyShift, ebiten.KeyZ)
+	if tf.Text != "hello" {
+		t.Fatalf("ctrl+shift+z: got %q, want %q", tf.Text, "hello")
+	}
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyY)
+	if tf.Text != "hellXYo" || tf.CursorPosition != 6 {
+		t.Fatalf("ctrl+y: got %q cursor %d", tf.Text, tf.CursorPosition)
+	}
+}
+
+func TestSetValueClearsHistory(t *testing.T) {
+	tf, h := newField(t, 50)
+	tf.Activate()
+
+	h.TypeString("draft")
+	tf.SetValue("loaded")
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyZ)
+	if tf.Text != "loaded" || tf.CanUndo() {
+		t.Fatalf("undo after SetValue: got %q, want the loaded value and no history", tf.Text)
+	}
+
+	h.TypeString("!")
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyZ)
+	if tf.Text != "loaded" {
+		t.Fatalf("undoing an edit after SetValue: got %q, want %q", tf.Text, "loaded")
+	}
+}
+
+func TestUndoAtomicEdits(t *testing.T) {
+	clipboard := fakeClipboard(t)
+	*clipboard = "PASTE"
+	tf, h := newField(t, 50)
+	tf.Activate()
+
+	h.TypeString("abc")
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyV)
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyV)
+	if tf.Text != "abcPASTEPASTE" {
+		t.Fatalf("got %q", tf.Text)
+	}
+	tf.Undo()
+	if tf.Text != "abcPASTE" {
+		t.Fatalf("undo second paste: got %q", tf.Text)
+	}
+
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyA)
+	h.PressKeys(ebiten.KeyBackspace)
+	if tf.Text != "" {
+		t.Fatalf("delete selection: got %q", tf.Text)
+	}
+	tf.Undo()
+	if tf.Text != "abcPASTE" || tf.SelectedText() != "abcPASTE" {
+		t.Fatalf("undo delete selection: got %q selected %q", tf.Text, tf.SelectedText())
+	}
+
+	// A held backspace is undone in one step.
+	tf.ClearSelection()
+	h.HoldKeys(60, ebiten.KeyBackspace)
+	if tf.Text != "" {
+		t.Fatalf("backspace hold left %q", tf.Text)
+	}
+	tf.Undo()
+	if tf.Text != "abcPASTE" {
+		t.Fatalf("undo backspace hold: got %q", tf.Text)
+	}
+}
+
+func TestHistoryLimitAndClear(t *testing.T) {
+	clipboard := fakeClipboard(t)
+	*clipboard = "x"
+	tf, h := newField(t, 50)
+	tf.SetHistoryLimit(2)
+	tf.Activate()
+
+	for i := 0; i < 5; i++ {
+		h.PressKeys(ebiten.KeyControl, ebiten.KeyV)
+	}
+	for tf.Undo() {
+	}
+	if tf.Text != "xxx" {
+		t.Fatalf("undo past the limit: got %q, want %q", tf.Text, "xxx")
+	}
+
+	tf.ClearHistory()
+	if tf.CanUndo() || tf.CanRedo() || tf.Redo() {
+		t.Fatal("history not cleared")
+	}
+}
+
+func TestMultiByteTypingAndDeletion(t *testing.T) {
+	tests := []struct {
+		name  string
+		typed string
+		left  int // arrow presses before the backspace
+		want  string
+	}{
+		{"polish", "zażółć", 2, "zażłć"},
+		{"japanese", "日本語", 1, "日語"},
+		{"emoji", "a😀b", 1, "ab"},
+		{"flags", "🇯🇵🇵🇱", 0, "🇯🇵"},
+		{"zwj sequence", "x👩‍💻y", 1, "xy"},
+		{"combining mark", "cafe\u0301!", 1, "caf!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tf, h := newField(t, 50)
+			tf.Activate()
+			h.TypeString(tt.typed)
+			for i := 0; i < tt.left; i++ {
+				h.PressKeys(ebiten.KeyArrowLeft)
+			}
+			h.PressKeys(ebiten.KeyBackspace)
+			if tf.Text != tt.want {
+				t.Fatalf("got %q, want %q", tf.Text, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursorCountsGraphemes(t *testing.T) {
+	tf, h := newField(t, 50)
+	tf.Activate()
+
+	h.TypeString("e\u0301")
+	if tf.CursorPosition != 1 {
+		t.Fatalf("combining mark: cursor %d, want 1", tf.CursorPosition)
+	}
+	h.TypeString("日本🇵🇱")
+	if tf.CursorPosition != 4 {
+		t.Fatalf("cursor %d, want 4", tf.CursorPosition)
+	}
+	h.PressKeys(ebiten.KeyArrowLeft)
+	h.PressKeys(ebiten.KeyShift, ebiten.KeyArrowLeft)
+	if got := tf.SelectedText(); got != "本" {
+		t.Fatalf("selected %q, want %q", got, "本")
+	}
+	h.TypeString("ó")
+	if tf.Text != "e\u0301日ó🇵🇱" {
+		t.Fatalf("got %q", tf.Text)
+	}
+}
+
+func TestMaxLengthCountsGraphemes(t *testing.T) {
+	tf, h := newField(t, 3)
+	tf.Activate()
+
+	h.TypeString("ąę👍🏽ł")
+	if tf.Text != "ąę👍🏽" {
+		t.Fatalf("got %q, want %q", tf.Text, "ąę👍🏽")
+	}
+
+	// Marks that merge into an existing character still fit.
+	tf.SetValue("abc")
+	h.TypeString("\u0301")
+	if tf.Text != "abc\u0301" {
+		t.Fatalf("combining mark rejected at max length: %q", tf.Text)
+	}
+}
+
+func TestPasteAndSetValueTruncateGraphemes(t *testing.T) {
+	clipboard := fakeClipboard(t)
+	*clipboard = "ゲーム👩‍💻とても"
+	tf, h := newField(t, 6)
+	tf.SetValue("ab")
+	tf.Activate()
+
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyV)
+	if tf.Text != "abゲーム👩‍💻" || tf.CursorPosition != 6 {
+		t.Fatalf("paste: got %q cursor %d", tf.Text, tf.CursorPosition)
+	}
+
+	tf.SetValue("Zażółć gęślą jaźń")
+	if tf.Text != "Zażółć" || tf.CursorPosition != 6 {
+		t.Fatalf("SetValue: got %q cursor %d", tf.Text, tf.CursorPosition)
+	}
+}
+
+func TestDoubleClickSelectsAccentedWord(t *testing.T) {
+	tf, h := newFieldWithFont(t, "ab cafe\u0301 d")
+
+	h.Click(boundaryX(4), 20)
+	h.Click(boundaryX(4), 20)
+	if got := tf.SelectedText(); got != "cafe\u0301" {
+		t.Fatalf("selected %q", got)
+	}
+}
+
+func TestHorizontalScrollFollowsCursor(t *testing.T) {
+	// 30 characters are 210px wide, the view is 189px.
+	tf, h := newFieldWithFont(t, strings.Repeat("abcdefghij", 3))
+	h.Frame()
+	if tf.ScrollX != 21 {
+		t.Fatalf("ScrollX = %v with the cursor at the end, want 21", tf.ScrollX)
+	}
+
+	h.PressKeys(ebiten.KeyHome)
+	if tf.ScrollX != 0 {
+		t.Fatalf("ScrollX = %v after Home, want 0", tf.ScrollX)
+	}
+	h.PressKeys(ebiten.KeyEnd)
+	if tf.ScrollX != 21 {
+		t.Fatalf("ScrollX = %v after End, want 21", tf.ScrollX)
+	}
+
+	// Clicking maps through the scroll offset: x = 15 + 7*10 - 21 is boundary 10.
+	h.Click(15+7*10-21, 20)
+	if tf.CursorPosition != 10 || tf.ScrollX != 21 {
+		t.Fatalf("click: cursor %d ScrollX %v, want cursor 10 ScrollX 21", tf.CursorPosition, tf.ScrollX)
+	}
+
+	// Deleting text scrolls back so no empty space is left on the right.
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyA)
+	h.PressKeys(ebiten.KeyBackspace)
+	if tf.ScrollX != 0 {
+		t.Fatalf("ScrollX = %v after clearing, want 0", tf.ScrollX)
+	}
+}
+
+func TestDragPastEdgeScrolls(t *testing.T) {
+	tf, h := newFieldWithFont(t, strings.Repeat("abcdefghij", 3))
+	h.PressKeys(ebiten.KeyHome)
+
+	h.Press(boundaryX(0), 20)
+	h.MoveCursor(300, 20)
+	h.Release(300, 20)
+	if tf.SelectedText() != tf.Text || tf.ScrollX != 21 {
+		t.Fatalf("selected %q with ScrollX %v", tf.SelectedText(), tf.ScrollX)
+	}
+}
+
+func TestClickPlacesCursorAtNearestBoundary(t *testing.T) {
+	face := testutil.GoRegular(20)
+	tf, h := newField(t, 50)
+	tf.SetFont(face)
+	tf.SetValue("iWiW")
+
+	// Screen x of the boundary before character i: bounds x + padding + advance.
+	boundary := func(i int) float32 {
+		return 15 + float32(font.MeasureString(face, tf.Text[:i]))/64
+	}
+	tests := []struct {
+		x    float32
+		want int
+	}{
+		{12, 0},                              // in the padding
+		{boundary(1) + 1, 1},                 // just inside the wide W
+		{(boundary(1)+boundary(2))/2 + 1, 2}, // past the middle of the W
+		{(boundary(2)+boundary(3))/2 - 1, 2}, // before the middle of the narrow i
+		{boundary(4) + 30, 4},                // right of the text
+	}
+	for _, tt := range tests {
+		h.Click(int(tt.x), 20)
+		h.Advance(30) // keep clicks from counting as double clicks
+		if tf.CursorPosition != tt.want {
+			t.Errorf("click at x=%v: cursor %d, want %d", tt.x, tf.CursorPosition, tt.want)
+		}
+	}
+}
+
+func TestMaskedFieldDrawsMaskPerCharacter(t *testing.T) {
+	tf, _ := newField(t, 20)
+	tf.SetMasked(true)
+	tf.SetValue("pa\u0301ss")
+	if got := tf.DisplayText(); got != "••••" {
+		t.Fatalf("display %q, want four mask runes", got)
+	}
+	tf.SetMaskRune('*')
+	if got := tf.DisplayText(); got != "****" {
+		t.Fatalf("display %q with custom mask", got)
+	}
+	tf.SetRevealed(true)
+	if got := tf.DisplayText(); got != tf.Text {
+		t.Fatalf("revealed display %q, want %q", got, tf.Text)
+	}
+}
+
+func TestMaskedFieldDoesNotCopyOrCut(t *testing.T) {
+	clipboard := fakeClipboard(t)
+	tf, h := newField(t, 20)
+	tf.SetMasked(true)
+	tf.SetCopyAllIfNoSelection(true)
+	tf.SetValue("hunter2")
+	tf.Activate()
+
+	for _, revealed := range []bool{false, true} {
+		tf.SetRevealed(revealed)
+		tf.SelectAll()
+		h.PressKeys(ebiten.KeyControl, ebiten.KeyC)
+		h.PressKeys(ebiten.KeyControl, ebiten.KeyX)
+		if *clipboard != "" || tf.Text != "hunter2" {
+			t.Fatalf("revealed=%v: clipboard %q text %q", revealed, *clipboard, tf.Text)
+		}
+	}
+
+	// Pasting into a password field still works.
+	*clipboard = "!"
+	tf.ClearSelection()
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyV)
+	if tf.Text != "hunter2!" {
+		t.Fatalf("paste: text %q", tf.Text)
+	}
+}
+
+func TestMaskedFieldRevealsLastTypedCharacter(t *testing.T) {
+	tf, h := newField(t, 20)
+	tf.SetMasked(true)
+	tf.SetMaskRune('*')
+	tf.SetRevealDuration(0.5)
+	tf.Activate()
+
+	h.TypeString("ab")
+	if got := tf.DisplayText(); got != "*b" {
+		t.Fatalf("after typing: display %q", got)
+	}
+	h.Advance(30)
+	if got := tf.DisplayText(); got != "**" {
+		t.Fatalf("after the reveal duration: display %q", got)
+	}
+
+	h.TypeString("c")
+	h.PressKeys(ebiten.KeyArrowLeft)
+	if got := tf.DisplayText(); got != "***" {
+		t.Fatalf("after moving the cursor: display %q", got)
+	}
+}
+
+func TestMaskedFieldDoubleClickSelectsAll(t *testing.T) {
+	tf, h := newFieldWithFont(t, "two words")
+	tf.SetMasked(true)
+
+	h.Click(boundaryX(1), 20)
+	h.Click(boundaryX(1), 20)
+	if start, end := tf.Selection(); start != 0 || end != 9 {
+		t.Fatalf("selection %d-%d, want the whole text", start, end)
+	}
+}
+
+func TestRevealToggle(t *testing.T) {
+	tf, h := newField(t, 20)
+	tf.SetMasked(true)
+	toggle := textfield.NewRevealToggle(tf, 215, 10, 30, 30)
+	h.Add(toggle)
+
+	h.Click(230, 25)
+	if !tf.IsRevealed() {
+		t.Fatal("first click did not reveal the field")
+	}
+	h.Click(230, 25)
+	if tf.IsRevealed() {
+		t.Fatal("second click did not hide the field")
+	}
+}
+
+func TestAllowRuneFiltersTypingAndPaste(t *testing.T) {
+	clipboard := fakeClipboard(t)
+	tf, h := newField(t, 20)
+	tf.SetAllowRune(validate.AllowInteger)
+	tf.Activate()
+
+	h.TypeString("8a0b8")
+	if tf.Text != "808" {
+		t.Fatalf("after typing: text %q", tf.Text)
+	}
+	*clipboard = "0 port"
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyV)
+	if tf.Text != "8080" || tf.CursorPosition != 4 {
+		t.Fatalf("after paste: text %q cursor %d", tf.Text, tf.CursorPosition)
+	}
+}
+
+func TestValidateRunsOnChange(t *testing.T) {
+	tf, h := newField(t, 20)
+	tf.SetValidator(validate.IntegerRange(1, 65535))
+	tf.Activate()
+
+	h.Frame()
+	if !tf.IsValid() {
+		t.Fatalf("untouched empty field is invalid: %v", tf.ValidationError())
+	}
+
+	h.TypeString("7000")
+	if !tf.IsValid() {
+		t.Fatalf("7000 is invalid: %v", tf.ValidationError())
+	}
+	h.TypeString("0")
+	if tf.IsValid() {
+		t.Fatal("70000 is valid")
+	}
+	h.PressKeys(ebiten.KeyBackspace)
+	if !tf.IsValid() {
+		t.Fatalf("back to 7000 is invalid: %v", tf.ValidationError())
+	}
+
+	tf.SetValue("0")
+	if tf.IsValid() {
+		t.Fatal("SetValue did not revalidate")
+	}
+
+	// Assigning Text directly is picked up on the next frame.
+	tf.Text = "25565"
+	h.Frame()
+	if !tf.IsValid() {
+		t.Fatalf("direct assignment not revalidated: %v", tf.ValidationError())
+	}
+}
+
+func TestChangeAndFocusEvents(t *testing.T) {
+	tf, h := newField(t, 20)
+	var events []string
+	tf.OnChange = func(old, new string) { events = append(events, "change "+old+">"+new) }
+	tf.OnFocus = func() { events = append(events, "focus") }
+	tf.OnBlur = func() { events = append(events, "blur") }
+
+	h.Click(50, 20)
+	h.TypeString("ab")
+	h.PressKeys(ebiten.KeyBackspace)
+	tf.SetValue("xyz")
+	h.Frame()
+	h.Click(300, 300)
+
+	want := []string{"focus", "change >a", "change a>ab", "change ab>a", "change a>xyz", "blur"}
+	if strings.Join(events, "|") != strings.Join(want, "|") {
+		t.Fatalf("events %q, want %q", events, want)
+	}
+}
+
+func TestSubmitEvent(t *testing.T) {
+	tf, h := newField(t, 20)
+	tf.SetValidator(validate.Integer())
+	var submitted []string
+	tf.OnSubmit = func(text string) { submitted = append(submitted, text) }
+	tf.Activate()
+
+	h.TypeString("4x")
+	h.PressKeys(ebiten.KeyEnter)
+	if len(submitted) != 0 {
+		t.Fatalf("invalid text submitted: %q", submitted)
+	}
+	h.PressKeys(ebiten.KeyBackspace)
+	h.PressKeys(ebiten.KeyEnter)
+	h.PressKeys(ebiten.KeyNumpadEnter)
+	if strings.Join(submitted, "|") != "4|4" {
+		t.Fatalf("submitted %q, want 4 twice", submitted)
+	}
+	if !tf.IsActive {
+		t.Fatal("submitting deactivated the field")
+	}
+}
+
+func TestCancelEvent(t *testing.T) {
+	for _, revert := range []bool{false, true} {
+		tf, h := newField(t, 20)
+		tf.SetValue("old")
+		tf.SetRevertOnCancel(revert)
+		cancelled := 0
+		tf.OnCancel = func() { cancelled++ }
+
+		h.Click(50, 20)
+		h.TypeString("er")
+		h.PressKeys(ebiten.KeyEscape)
+
+		want := "older"
+		if revert {
+			want = "old"
+		}
+		if tf.Text != want || cancelled != 1 || tf.IsActive {
+			t.Fatalf("revert=%v: text %q, %d cancels, active %v", revert, tf.Text, cancelled, tf.IsActive)
+		}
+		if revert && (!tf.Undo() || tf.Text != "older") {
+			t.Fatalf("reverting is not undoable: text %q", tf.Text)
+		}
+	}
+}
+
+func TestWordNavigation(t *testing.T) {
+	tf, h := newField(t, 50)
+	tf.SetValue("one, two-three  four")
+	tf.Activate()
+
+	// Word starts: one=0 two=5 three=9 four=16.
+	for _, want := range []int{16, 9, 5, 0, 0} {
+		h.PressKeys(ebiten.KeyControl, ebiten.KeyArrowLeft)
+		if tf.CursorPosition != want {
+			t.Fatalf("ctrl+left: cursor %d, want %d", tf.CursorPosition, want)
+		}
+	}
+	// Word ends: one=3 two=8 three=14 four=20.
+	for _, want := range []int{3, 8, 14, 20} {
+		h.PressKeys(ebiten.KeyControl, ebiten.KeyArrowRight)
+		if tf.CursorPosition != want {
+			t.Fatalf("ctrl+right: cursor %d, want %d", tf.CursorPosition, want)
+		}
+	}
+
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyShift, ebiten.KeyArrowLeft)
+	if tf.SelectedText() != "four" {
+		t.Fatalf("ctrl+shift+left selected %q", tf.SelectedText())
+	}
+}
+
+func TestWordDeletion(t *testing.T) {
+	tf, h := newField(t, 50)
+	tf.SetValue("hello wide world")
+	tf.Activate()
+
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyBackspace)
+	if tf.Text != "hello wide " {
+		t.Fatalf("ctrl+backspace: text %q", tf.Text)
+	}
+	h.PressKeys(ebiten.KeyHome)
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyDelete)
+	if tf.Text != " wide " || tf.CursorPosition != 0 {
+		t.Fatalf("ctrl+delete: text %q cursor %d", tf.Text, tf.CursorPosition)
+	}
+	tf.Undo()
+	if tf.Text != "hello wide " {
+		t.Fatalf("undo: text %q", tf.Text)
+	}
+}
+
+func TestForwardDelete(t *testing.T) {
+	tf, h := newField(t, 50)
+	tf.SetValue("cafés")
+	tf.Activate()
+	h.PressKeys(ebiten.KeyHome)
+	h.PressKeys(ebiten.KeyArrowRight)
+	h.PressKeys(ebiten.KeyArrowRight)
+	h.PressKeys(ebiten.KeyArrowRight)
+
+	h.PressKeys(ebiten.KeyDelete)
+	if tf.Text != "cafs" || tf.CursorPosition != 3 {
+		t.Fatalf("delete: text %q cursor %d", tf.Text, tf.CursorPosition)
+	}
+	h.PressKeys(ebiten.KeyEnd)
+	h.PressKeys(ebiten.KeyDelete)
+	if tf.Text != "cafs" {
+		t.Fatalf("delete at the end changed the text to %q", tf.Text)
+	}
+
+	tf.SetSelection(0, 2)
+	h.PressKeys(ebiten.KeyDelete)
+	if tf.Text != "fs" {
+		t.Fatalf("delete with selection: text %q", tf.Text)
+	}
+
+	h.HoldKeys(40, ebiten.KeyHome)
+	h.HoldKeys(40, ebiten.KeyDelete)
+	if tf.Text != "" {
+		t.Fatalf("holding delete left %q", tf.Text)
+	}
+}
+
+func TestMacWordKeys(t *testing.T) {
+	tf, h := newField(t, 50)
+	mac := input.MacKeymap()
+	tf.SetKeymap(&mac)
+	tf.SetValue("alpha beta")
+	tf.Activate()
+
+	h.PressKeys(ebiten.KeyAlt, ebiten.KeyArrowLeft)
+	if tf.CursorPosition != 6 {
+		t.Fatalf("option+left: cursor %d, want 6", tf.CursorPosition)
+	}
+	h.PressKeys(ebiten.KeyAlt, ebiten.KeyBackspace)
+	if tf.Text != "beta" {
+		t.Fatalf("option+backspace: text %q", tf.Text)
+	}
+}
+
+func TestMaskedWordNavigationTreatsTextAsOneWord(t *testing.T) {
+	tf, h := newField(t, 50)
+	tf.SetMasked(true)
+	tf.SetValue("two words")
+	tf.Activate()
+
+	h.PressKeys(ebiten.KeyControl, ebiten.KeyArrowLeft)
+	if tf.CursorPosition != 0 {
+		t.Fatalf("ctrl+left in a masked field: cursor %d, want 0", tf.CursorPosition)
+	}
+}
+
+func TestHeldKeysRepeat(t *testing.T) {
+	tf, h := newField(t, 50)
+	// 60 frames a second: the first repeat comes after 30 frames, then every 6.
+	// Frame counts stay clear of the exact repeat times.
+	tf.SetKeyRepeat(&input.KeyRepeat{Delay: 0.5, Interval: 0.1})
+	tf.SetValue("0123456789abcdefghij")
+	tf.Activate()
+
+	h.Input.PressKey(ebiten.KeyArrowLeft)
+	h.Advance(30)
+	if tf.CursorPosition != 19 {
+		t.Fatalf("before the delay: cursor %d, want 19", tf.CursorPosition)
+	}
+	h.Advance(14)
+	if tf.CursorPosition != 16 {
+		t.Fatalf("after the delay: cursor %d, want 16", tf.CursorPosition)
+	}
+	h.Input.ReleaseKey(ebiten.KeyArrowLeft)
+	h.Advance(30)
+	if tf.CursorPosition != 16 {
+		t.Fatalf("after release: cursor %d, want 16", tf.CursorPosition)
+	}
+
+	h.Input.PressKey(ebiten.KeyDelete)
+	h.Advance(33)
+	if tf.Text != "0123456789abcdefij" {
+		t.Fatalf("holding delete: text %q", tf.Text)
+	}
+	h.Input.ReleaseKey(ebiten.KeyDelete)
+	h.Frame()
+
+	h.Input.PressKey(ebiten.KeyControl, ebiten.KeyZ)
+	h.Advance(33)
+	if tf.Text != "0123456789abcdefghij" {
+		t.Fatalf("holding undo: text %q", tf.Text)
+	}
+}
+
+func TestBackspaceHoldTimerSetsTheRepeatDelay(t *testing.T) {
+	tf, h := newField(t, 50)
+	tf.BackspaceHoldTimer = 0.25
+	tf.SetValue("0123456789")
+	tf.Activate()
+
+	// Default 30 repeats a second after the quarter second delay: the press,
+	// then repeats at frames 16, 18 and 20.
+	h.HoldKeys(20, ebiten.KeyBackspace)
+	if tf.Text != "012345" {
+		t.Fatalf("holding backspace with a 0.25s BackspaceHoldTimer: text %q, want %q", tf.Text, "012345")
+	}
+
+	// KeyRepeat takes precedence over the deprecated field.
+	tf.SetKeyRepeat(&input.KeyRepeat{Delay: 1, Interval: 0.1})
+	h.HoldKeys(20, ebiten.KeyBackspace)
+	if tf.Text != "01234" {
+		t.Fatalf("holding backspace with a 1s KeyRepeat delay: text %q, want %q", tf.Text, "01234")
+	}
+}
+
+func TestTimersFollowTheClock(t *testing.T) {
+	tf, h := newField(t, 20)
+	tf.SetMasked(true)
+	tf.SetMaskRune('*')
+	tf.SetRevealDuration(0.5)
+	tf.Activate()
+
+	// At 30 updates a second the half second reveal lasts 15 frames.
+	// Frame counts stay clear of the exact expiry time.
+	h.Clock.Seconds = 1.0 / 30
+	h.TypeString("a")
+	h.Advance(13)
+	if tf.DisplayText() != "a" {
+		t.Fatalf("revealed character hidden early: %q", tf.DisplayText())
+	}
+	h.Advance(3)
+	if tf.DisplayText() != "*" {
+		t.Fatalf("revealed character still shown: %q", tf.DisplayText())
+	}
+
+	// A field with its own clock ignores the default one.
+	tf.SetClock(clock.NewFixed(0.25))
+	tf.CursorBlinkTimer = 0
+	h.Frame()
+	if tf.CursorBlinkTimer != 0.25 {
+		t.Fatalf("blink timer %v, want 0.25", tf.CursorBlinkTimer)
+	}
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+package textfield
+
+import (
+	"image/color"
+	"strings"
+)
+
+// SetAllowRune sets the filter for typed and pasted runes. Runes it rejects are
+// dropped; nil allows everything. Text set with SetValue is not filtered.
+func (tf *TextField) SetAllowRune(allow func(rune) bool) {
+	tf.AllowRune = allow
+}
+
+// SetValidator sets the function that checks the text whenever it changes.
+// Passing nil makes every value valid. The current text is not checked until
+// it changes or Revalidate is called, so an untouched form shows no errors.
+func (tf *TextField) SetValidator(validate func(string) error) {
+	tf.Validate = validate
+	if validate == nil {
+		tf.validationErr = nil
+	}
+}
+
+// SetInvalidColors sets the colors used while the text fails validation.
+func (tf *TextField) SetInvalidColors(background, border, errorText color.RGBA) {
+	tf.InvalidBackgroundColor = background
+	tf.InvalidBorderColor = border
+	tf.ErrorTextColor = errorText
+}
+
+// SetShowErrorMessage sets whether the validation error is drawn below the field.
+func (tf *TextField) SetShowErrorMessage(show bool) {
+	tf.ShowErrorMessage = show
+}
+
+// Revalidate runs Validate on the current text, stores and returns the result.
+// It runs automatically whenever the text changes.
+func (tf *TextField) Revalidate() error {
+	tf.validatedText = tf.Text
+	tf.validationErr = nil
+	if tf.Validate != nil {
+		tf.validationErr = tf.Validate(tf.Text)
+	}
+	return tf.validationErr
+}
+
+// ValidationError returns the error from the last validation, or nil if the
+// text is valid.
+func (tf *TextField) ValidationError() error {
+	return tf.validationErr
+}
+
+// IsValid reports whether the text passed the last validation.
+func (tf *TextField) IsValid() bool {
+	return tf.validationErr == nil
+}
+
+// revalidateIfChanged validates the text if it changed since the last
+// validation, including changes made by assigning Text directly.
+func (tf *TextField) revalidateIfChanged() {
+	if tf.Text != tf.validatedText {
+		tf.Revalidate()
+	}
+}
+
+// allowed reports whether r passes the AllowRune filter.
+func (tf *TextField) allowed(r rune) bool {
+	return tf.AllowRune == nil || tf.AllowRune(r)
+}
+
+// filter drops the runes of s that AllowRune rejects.
+func (tf *TextField) filter(s string) string {
+	if tf.AllowRune == nil {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if tf.AllowRune(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
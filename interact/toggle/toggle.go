@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: MIT
+
+// Package toggle provides checkboxes and switches: buttons that remember
+// whether they are on.
+package toggle
+
+import (
+	"image/color"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/button"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/clock"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/draw"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/internal/paint"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+// Kind is how a toggle is drawn.
+type Kind int
+
+const (
+	Checkbox Kind = iota // A box with a check mark
+	Switch               // A track with a knob that slides across
+)
+
+// checkSpeed is how much of the check animation runs per second.
+const checkSpeed = 8.0
+
+// Toggle is a checkbox or switch with a label to its right. The whole of
+// Bounds is clickable. It embeds a Button for hovering, pressing, colors,
+// font and input; BackgroundColor, HoverColor and PressedColor fill the box
+// or track while unchecked.
+type Toggle struct {
+	*button.Button
+	Kind          Kind
+	Checked       bool
+	Indeterminate bool // Neither checked nor unchecked, as for a partly selected group
+	TriState      bool // Clicks cycle unchecked, checked, indeterminate
+	CheckedColor  color.RGBA
+	MarkColor     color.RGBA // The check mark, dash or switch knob
+	CheckProgress float32    // Animates from 0 when off to 1 when on
+
+	// OnToggle is called from Update when a click changes the state, with the
+	// new value of Checked. Indeterminate is already updated.
+	OnToggle func(checked bool)
+}
+
+// New creates an unchecked toggle of the given kind.
+func New(kind Kind, x, y, width, height float32, label string) *Toggle {
+	return &Toggle{
+		Button:       button.NewButton(x, y, width, height, label),
+		Kind:         kind,
+		CheckedColor: color.RGBA{R: 0, G: 120, B: 215, A: 255}, // Blue
+		MarkColor:    color.RGBA{R: 255, G: 255, B: 255, A: 255},
+	}
+}
+
+// NewCheckbox creates an unchecked checkbox.
+func NewCheckbox(x, y, width, height float32, label string) *Toggle {
+	return New(Checkbox, x, y, width, height, label)
+}
+
+// NewSwitch creates a switch that is off.
+func NewSwitch(x, y, width, height float32, label string) *Toggle {
+	return New(Switch, x, y, width, height, label)
+}
+
+// SetChecked checks or unchecks the toggle and clears Indeterminate, without
+// calling OnToggle.
+func (t *Toggle) SetChecked(checked bool) {
+	t.Checked = checked
+	t.Indeterminate = false
+}
+
+// SetIndeterminate puts the toggle in or out of the indeterminate state,
+// without calling OnToggle. Entering it unchecks the toggle, so leaving it
+// leaves the toggle unchecked; clearing it on a toggle that is not
+// indeterminate keeps Checked as it is.
+func (t *Toggle) SetIndeterminate(indeterminate bool) {
+	if indeterminate {
+		t.Checked = false
+	}
+	t.Indeterminate = indeterminate
+}
+
+// SetTriState sets whether clicks cycle through the indeterminate state.
+func (t *Toggle) SetTriState(triState bool) {
+	t.TriState = triState
+}
+
+// SetCheckedColors sets the fill of a checked box or switch track, and the
+// color of the check mark or knob.
+func (t *Toggle) SetCheckedColors(checked, mark color.RGBA) {
+	t.CheckedColor = checked
+	t.MarkColor = mark
+}
+
+// Update should be called every frame.
+func (t *Toggle) Update() {
+	t.Button.Update()
+	if t.IsClicked() {
+		t.toggle()
+	}
+
+	target := float32(0)
+	switch {
+	case t.Indeterminate && t.Kind == Switch:
+		target = 0.5 // The knob waits in the middle.
+	case t.Checked || t.Indeterminate:
+		target = 1
+	}
+	step := clock.Or(t.Clock).Delta() * checkSpeed
+	if t.CheckProgress < target {
+		t.CheckProgress = min(t.CheckProgress+step, target)
+	} else {
+		t.CheckProgress = max(t.CheckProgress-step, target)
+	}
+}
+
+// toggle advances the state after a click and calls OnToggle. A tri-state
+// toggle cycles unchecked, checked, indeterminate; any other toggle that was
+// made indeterminate is checked.
+func (t *Toggle) toggle() {
+	switch {
+	case t.Indeterminate:
+		t.Checked, t.Indeterminate = !t.TriState, false
+	case t.Checked && t.TriState:
+		t.Checked, t.Indeterminate = false, true
+	default:
+		t.Checked = !t.Checked
+	}
+	if t.OnToggle != nil {
+		t.OnToggle(t.Checked)
+	}
+}
+
+// Draw draws the box or switch and the label.
+func (t *Toggle) Draw(screen *ebiten.Image) {
+	if t.Invisible {
+		return
+	}
+
+	// The box or track is as tall as the bounds less the padding, at the left.
+	size := max(t.Bounds.H-2*t.Padding, 0)
+	x, y := t.Bounds.X+t.Padding, t.Bounds.Y+(t.Bounds.H-size)/2
+	var width float32
+	if t.Kind == Switch {
+		width = t.drawSwitch(screen, x, y, size)
+	} else {
+		width = t.drawCheckbox(screen, x, y, size)
+	}
+
+	if t.FontFace == nil || t.Label == "" {
+		return
+	}
+	textX := x + width + t.Padding
+	textY := t.Bounds.Y + (t.Bounds.H-float32(t.FontSize))/2
+	text.Draw(screen, t.Label, t.FontFace, int(textX), int(textY)+int(t.FontSize), t.color(t.TextColor))
+}
+
+// drawCheckbox draws the box at x, y and returns its width.
+func (t *Toggle) drawCheckbox(screen *ebiten.Image, x, y, size float32) float32 {
+	radii := draw.Uniform(size * 0.2)
+	fill := paint.LerpColor(t.uncheckedColor(), t.CheckedColor, t.CheckProgress)
+	draw.FillRoundedRect(screen, x, y, size, size, radii, t.color(fill))
+	draw.StrokeRoundedRect(screen, x, y, size, size, radii, 2, t.color(t.BorderColor))
+
+	// The mark fades in as the box fills.
+	mark := t.color(paint.Fade(t.MarkColor, t.CheckProgress))
+	stroke := max(size*0.12, 1.5)
+	if t.Indeterminate {
+		draw.Line(screen, x+size*0.25, y+size/2, x+size*0.75, y+size/2, stroke, mark)
+	} else {
+		check := [...]draw.Point{
+			{X: x + size*0.22, Y: y + size*0.52},
+			{X: x + size*0.42, Y: y + size*0.72},
+			{X: x + size*0.78, Y: y + size*0.3},
+		}
+		draw.Polyline(screen, check[:], stroke, mark)
+	}
+	return size
+}
+
+// drawSwitch draws the track at x, y with its knob and returns its width.
+func (t *Toggle) drawSwitch(screen *ebiten.Image, x, y, size float32) float32 {
+	width := size * 2
+	radii := draw.Uniform(size / 2)
+	fill := paint.LerpColor(t.uncheckedColor(), t.CheckedColor, t.CheckProgress)
+	draw.FillRoundedRect(screen, x, y, width, size, radii, t.color(fill))
+	draw.StrokeRoundedRect(screen, x, y, width, size, radii, 2, t.color(t.BorderColor))
+
+	knob := size/2 - 3
+	cx := x + size/2 + (width-size)*t.CheckProgress
+	draw.FillCircle(screen, cx, y+size/2, max(knob, 1), t.color(t.MarkColor))
+	draw.StrokeCircle(screen, cx, y+size/2, max(knob, 1), 1, t.color(t.BorderColor))
+	return width
+}
+
+// uncheckedColor returns the box fill while unchecked, following the button's
+// hover and press animation.
+func (t *Toggle) uncheckedColor() color.RGBA {
+	if t.AnimationProgress <= 0.5 {
+		return paint.LerpColor(t.BackgroundColor, t.HoverColor, t.AnimationProgress*2)
+	}
+	return paint.LerpColor(t.HoverColor, t.PressedColor, (t.AnimationProgress-0.5)*2)
+}
+
+// color fades c if the toggle is disabled.
+func (t *Toggle) color(c color.RGBA) color.RGBA {
+	if !t.Enabled {
+		return paint.Fade(c, 0.5)
+	}
+	return c
+}
@@ -0,0 +1,121 @@
+package toggle_test
+
+import (
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/testutil"
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/toggle"
+)
+
+func TestClickTogglesAndNotifies(t *testing.T) {
+	c := toggle.NewCheckbox(10, 10, 200, 30, "Fullscreen")
+	var got []bool
+	c.OnToggle = func(checked bool) { got = append(got, checked) }
+	h := testutil.NewHarness(c)
+	defer h.Close()
+
+	// The label is clickable too, not just the box.
+	h.Click(150, 20)
+	if !c.Checked {
+		t.Fatal("click did not check the box")
+	}
+	h.Click(20, 20)
+	if c.Checked {
+		t.Fatal("second click did not uncheck the box")
+	}
+	if len(got) != 2 || !got[0] || got[1] {
+		t.Fatalf("OnToggle called with %v, want [true false]", got)
+	}
+
+	h.Click(300, 20)
+	if c.Checked || len(got) != 2 {
+		t.Fatal("click outside the bounds toggled the box")
+	}
+}
+
+func TestSetCheckedDoesNotNotify(t *testing.T) {
+	c := toggle.NewSwitch(10, 10, 200, 30, "VSync")
+	c.OnToggle = func(bool) { t.Fatal("OnToggle called by SetChecked") }
+	c.SetChecked(true)
+	if !c.Checked {
+		t.Fatal("SetChecked(true) left the switch off")
+	}
+}
+
+func TestTriStateCycle(t *testing.T) {
+	c := toggle.NewCheckbox(10, 10, 200, 30, "All")
+	c.SetTriState(true)
+	h := testutil.NewHarness(c)
+	defer h.Close()
+
+	type state struct{ checked, indeterminate bool }
+	want := []state{{true, false}, {false, true}, {false, false}, {true, false}}
+	for i, w := range want {
+		h.Click(20, 20)
+		if got := (state{c.Checked, c.Indeterminate}); got != w {
+			t.Fatalf("after click %d: %+v, want %+v", i+1, got, w)
+		}
+	}
+}
+
+func TestClickingIndeterminateChecks(t *testing.T) {
+	c := toggle.NewCheckbox(10, 10, 200, 30, "All")
+	c.SetIndeterminate(true)
+	h := testutil.NewHarness(c)
+	defer h.Close()
+
+	h.Click(20, 20)
+	if !c.Checked || c.Indeterminate {
+		t.Fatalf("checked=%v indeterminate=%v, want checked", c.Checked, c.Indeterminate)
+	}
+}
+
+func TestSetIndeterminate(t *testing.T) {
+	c := toggle.NewCheckbox(10, 10, 200, 30, "All")
+	c.SetChecked(true)
+	c.SetIndeterminate(false)
+	if !c.Checked {
+		t.Fatal("clearing indeterminate on a checked box unchecked it")
+	}
+	c.SetIndeterminate(true)
+	c.SetIndeterminate(false)
+	if c.Checked || c.Indeterminate {
+		t.Fatalf("checked=%v indeterminate=%v after leaving indeterminate, want unchecked", c.Checked, c.Indeterminate)
+	}
+}
+
+func TestDisabledToggleIgnoresClicks(t *testing.T) {
+	c := toggle.NewCheckbox(10, 10, 200, 30, "Subtitles")
+	c.SetEnabled(false)
+	h := testutil.NewHarness(c)
+	defer h.Close()
+
+	h.Click(20, 20)
+	if c.Checked {
+		t.Fatal("disabled checkbox was checked")
+	}
+}
+
+func TestCheckAnimation(t *testing.T) {
+	c := toggle.NewSwitch(10, 10, 200, 30, "Music")
+	h := testutil.NewHarness(c)
+	defer h.Close()
+
+	c.SetChecked(true)
+	h.Frame()
+	if c.CheckProgress <= 0 || c.CheckProgress >= 1 {
+		t.Fatalf("progress %v after one frame, want part way", c.CheckProgress)
+	}
+	// The animation takes an eighth of a second.
+	h.Advance(8)
+	if c.CheckProgress != 1 {
+		t.Fatalf("progress %v, want 1 once the animation is over", c.CheckProgress)
+	}
+
+	// An indeterminate switch rests its knob in the middle.
+	c.SetIndeterminate(true)
+	h.Advance(10)
+	if c.CheckProgress != 0.5 {
+		t.Fatalf("indeterminate progress %v, want 0.5", c.CheckProgress)
+	}
+}
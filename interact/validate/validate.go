@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: MIT
+
+// Package validate provides ready-made validators and rune filters for text
+// fields. Validators have the signature of TextField.Validate and filters the
+// signature of TextField.AllowRune, so they can be assigned directly:
+//
+//	port.AllowRune = validate.AllowInteger
+//	port.Validate = validate.IntegerRange(1, 65535)
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Validator checks a text value, returning an error describing the problem
+// in words fit to show the user.
+type Validator func(string) error
+
+// ErrEmpty is returned by the built-in validators for empty text.
+var ErrEmpty = errors.New("required")
+
+// Integer accepts whole numbers such as "42" or "-7".
+func Integer() Validator {
+	return func(s string) error {
+		_, err := parseInt(s)
+		return err
+	}
+}
+
+// IntegerRange accepts whole numbers from min to max inclusive.
+func IntegerRange(min, max int) Validator {
+	return func(s string) error {
+		n, err := parseInt(s)
+		if err != nil {
+			return err
+		}
+		if n < min || n > max {
+			return fmt.Errorf("must be between %d and %d", min, max)
+		}
+		return nil
+	}
+}
+
+func parseInt(s string) (int, error) {
+	if s == "" {
+		return 0, ErrEmpty
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, errors.New("number is too large")
+		}
+		return 0, errors.New("must be a whole number")
+	}
+	return n, nil
+}
+
+// Float accepts decimal numbers such as "3.14", "-2" or "1e3".
+func Float() Validator {
+	return func(s string) error {
+		_, err := parseFloat(s)
+		return err
+	}
+}
+
+// FloatRange accepts decimal numbers from min to max inclusive.
+func FloatRange(min, max float64) Validator {
+	return func(s string) error {
+		f, err := parseFloat(s)
+		if err != nil {
+			return err
+		}
+		if f < min || f > max {
+			return fmt.Errorf("must be between %g and %g", min, max)
+		}
+		return nil
+	}
+}
+
+func parseFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, ErrEmpty
+	}
+	// ParseFloat also accepts "NaN", "Inf" and hex floats, which are not
+	// numbers anyone types into a settings menu.
+	if strings.ContainsFunc(s, func(r rune) bool { return !AllowFloat(r) }) {
+		return 0, errors.New("must be a number")
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, errors.New("number is too large")
+		}
+		return 0, errors.New("must be a number")
+	}
+	return f, nil
+}
+
+// Hex accepts one or more hexadecimal digits, such as "ff00cc".
+func Hex() Validator {
+	return func(s string) error {
+		if s == "" {
+			return ErrEmpty
+		}
+		if strings.ContainsFunc(s, func(r rune) bool { return !AllowHex(r) }) {
+			return errors.New("must be hexadecimal")
+		}
+		return nil
+	}
+}
+
+// Identifier accepts ASCII identifiers: a letter or underscore followed by
+// letters, digits and underscores, such as "player_1".
+func Identifier() Validator {
+	return func(s string) error {
+		if s == "" {
+			return ErrEmpty
+		}
+		if isDigit(rune(s[0])) {
+			return errors.New("must not start with a digit")
+		}
+		if strings.ContainsFunc(s, func(r rune) bool { return !AllowIdentifier(r) }) {
+			return errors.New("may only contain letters, digits and underscores")
+		}
+		return nil
+	}
+}
+
+// Regexp accepts text matching the pattern in full. message is the error shown
+// otherwise. It panics if the pattern does not compile, like regexp.MustCompile.
+func Regexp(pattern, message string) Validator {
+	re := regexp.MustCompile(`^(?:` + pattern + `)$`)
+	return func(s string) error {
+		if !re.MatchString(s) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// Optional accepts empty text and otherwise defers to v.
+func Optional(v Validator) Validator {
+	return func(s string) error {
+		if s == "" {
+			return nil
+		}
+		return v(s)
+	}
+}
+
+// All runs the validators in order and returns the first error.
+func All(validators ...Validator) Validator {
+	return func(s string) error {
+		for _, v := range validators {
+			if err := v(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// AllowInteger lets through the runes of a whole number.
+func AllowInteger(r rune) bool {
+	return isDigit(r) || r == '-' || r == '+'
+}
+
+// AllowFloat lets through the runes of a decimal number, including exponents.
+func AllowFloat(r rune) bool {
+	return AllowInteger(r) || r == '.' || r == 'e' || r == 'E'
+}
+
+// AllowHex lets through hexadecimal digits.
+func AllowHex(r rune) bool {
+	return isDigit(r) || r >= 'a' && r <= 'f' || r >= 'A' && r <= 'F'
+}
+
+// AllowIdentifier lets through ASCII letters, digits and underscores.
+func AllowIdentifier(r rune) bool {
+	return isDigit(r) || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '_'
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
@@ -0,0 +1,76 @@
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/OrtheSnowJames/ebiten-interactive/interact/validate"
+)
+
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       validate.Validator
+		valid   []string
+		invalid []string
+	}{
+		{"Integer", validate.Integer(), []string{"0", "42", "-7", "+3"}, []string{"", "4.2", "12a", "99999999999999999999"}},
+		{"IntegerRange", validate.IntegerRange(1, 65535), []string{"1", "8080", "65535"}, []string{"0", "65536", "-1", "port"}},
+		{"Float", validate.Float(), []string{"3.14", "-2", "1e3", ".5"}, []string{"", "NaN", "Inf", "0x1p-2", "1.2.3"}},
+		{"FloatRange", validate.FloatRange(0, 1), []string{"0", "0.5", "1"}, []string{"-0.1", "1.01"}},
+		{"Hex", validate.Hex(), []string{"ff00cc", "DEADbeef", "0"}, []string{"", "0x10", "ffg"}},
+		{"Identifier", validate.Identifier(), []string{"player_1", "_tmp", "X"}, []string{"", "1st", "has space", "naïve"}},
+		{"Regexp", validate.Regexp(`[a-z]+-\d+`, "bad code"), []string{"room-12"}, []string{"room-", "xroom-12!", "ROOM-1"}},
+		{"Optional", validate.Optional(validate.Integer()), []string{"", "5"}, []string{"five"}},
+		{"All", validate.All(validate.Integer(), validate.Regexp(`\d{4}`, "need four digits")), []string{"1234"}, []string{"123", "abcd"}},
+	}
+	for _, tt := range tests {
+		for _, s := range tt.valid {
+			if err := tt.v(s); err != nil {
+				t.Errorf("%s(%q) = %v, want valid", tt.name, s, err)
+			}
+		}
+		for _, s := range tt.invalid {
+			if err := tt.v(s); err == nil {
+				t.Errorf("%s(%q) accepted invalid input", tt.name, s)
+			}
+		}
+	}
+}
+
+func TestErrorMessages(t *testing.T) {
+	if err := validate.IntegerRange(1, 65535)("70000"); err == nil || err.Error() != "must be between 1 and 65535" {
+		t.Errorf("range error %v", err)
+	}
+	if err := validate.Regexp(`\d+`, "digits only")("x"); err == nil || err.Error() != "digits only" {
+		t.Errorf("regexp error %v", err)
+	}
+	if err := validate.Integer()(""); err != validate.ErrEmpty {
+		t.Errorf("empty error %v, want ErrEmpty", err)
+	}
+}
+
+func TestRuneFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		allow   func(rune) bool
+		allowed string
+		denied  string
+	}{
+		{"AllowInteger", validate.AllowInteger, "0123456789-+", "a.e "},
+		{"AllowFloat", validate.AllowFloat, "0123456789-+.eE", "xf,"},
+		{"AllowHex", validate.AllowHex, "0123456789abcdefABCDEF", "gxG-"},
+		{"AllowIdentifier", validate.AllowIdentifier, "azAZ09_", " -ï."},
+	}
+	for _, tt := range tests {
+		for _, r := range tt.allowed {
+			if !tt.allow(r) {
+				t.Errorf("%s(%q) = false", tt.name, r)
+			}
+		}
+		for _, r := range tt.denied {
+			if tt.allow(r) {
+				t.Errorf("%s(%q) = true", tt.name, r)
+			}
+		}
+	}
+}